@@ -0,0 +1,180 @@
+package ugform
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Direction is the direction text is written in
+type Direction int
+
+const (
+	// DirectionLTR lays text out from left to right
+	DirectionLTR Direction = iota
+	// DirectionRTL lays text out from right to left starting
+	// at the right edge of the field for languages such as
+	// Arabic and Hebrew
+	DirectionRTL
+	// DirectionAuto picks the direction from the first
+	// strongly directional character of the text falling back
+	// to left to right
+	DirectionAuto
+)
+
+// bidiType is the simplified bidi class of a grapheme cluster
+type bidiType int
+
+const (
+	bidiNeutral bidiType = iota
+	bidiLTR
+	bidiRTL
+	bidiNumber
+)
+
+// clusterType returns the bidi type of cluster c which is the
+// type of its first rune since the rest are combining marks
+func clusterType(c []rune) bidiType {
+	p, _ := bidi.LookupRune(c[0])
+	switch p.Class() {
+	case bidi.L:
+		return bidiLTR
+	case bidi.R, bidi.AL:
+		return bidiRTL
+	case bidi.EN, bidi.AN:
+		return bidiNumber
+	}
+	return bidiNeutral
+}
+
+// isRTL reports whether the first strongly directional
+// character of rs is written right to left
+func isRTL(rs []rune) bool {
+	for _, r := range rs {
+		switch clusterType([]rune{r}) {
+		case bidiLTR:
+			return false
+		case bidiRTL:
+			return true
+		}
+	}
+	return false
+}
+
+// visualOrder returns the indexes of clusters cs in the order
+// they appear on screen from left to right when the line's base
+// direction is rtl. It follows the outline of the Unicode bidi
+// algorithm without explicit embeddings: runs of the opposite
+// direction are reversed, numbers read left to right, and
+// neutrals such as spaces take the direction of the text around
+// them when both sides agree.
+func visualOrder(cs [][]rune, rtl bool) []int {
+	order := make([]int, len(cs))
+	for i := range order {
+		order[i] = i
+	}
+	types := make([]bidiType, len(cs))
+	mixed := rtl
+	for i, c := range cs {
+		types[i] = clusterType(c)
+		if types[i] == bidiRTL {
+			mixed = true
+		}
+	}
+	if !mixed {
+		return order
+	}
+	base, ltrLevel := 0, 0
+	if rtl {
+		base, ltrLevel = 1, 2
+	}
+	// numbers take the direction of the strong text before them
+	prev := bidiLTR
+	if rtl {
+		prev = bidiRTL
+	}
+	dirs := make([]bidiType, len(cs))
+	for i, t := range types {
+		switch t {
+		case bidiLTR, bidiRTL:
+			prev = t
+			dirs[i] = t
+		case bidiNumber:
+			dirs[i] = bidiNumber
+			if prev == bidiLTR {
+				dirs[i] = bidiLTR
+			}
+		}
+	}
+	levels := make([]int, len(cs))
+	for i, d := range dirs {
+		switch d {
+		case bidiLTR:
+			levels[i] = ltrLevel
+		case bidiRTL:
+			levels[i] = 1
+		case bidiNumber:
+			levels[i] = 2
+		default:
+			levels[i] = neutralLevel(dirs, i, base, ltrLevel)
+		}
+	}
+	// trailing whitespace goes back to the base direction
+	for i := len(cs) - 1; i >= 0 && unicode.IsSpace(cs[i][0]); i-- {
+		levels[i] = base
+	}
+	top := 0
+	for _, l := range levels {
+		if l > top {
+			top = l
+		}
+	}
+	// reverse every run at or above each level from the
+	// highest down to the lowest odd level
+	for level := top; level >= 1; level-- {
+		for i := 0; i < len(order); {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(order) && levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+// neutralLevel returns the level of the neutral cluster at i
+// which takes the direction of the strong text on either side
+// if they agree, numbers counting as right to left, and the
+// base direction otherwise
+func neutralLevel(dirs []bidiType, i, base, ltrLevel int) int {
+	side := func(step int) bidiType {
+		for j := i + step; j >= 0 && j < len(dirs); j += step {
+			switch dirs[j] {
+			case bidiLTR:
+				return bidiLTR
+			case bidiRTL, bidiNumber:
+				return bidiRTL
+			}
+		}
+		if base == 1 {
+			return bidiRTL
+		}
+		return bidiLTR
+	}
+	before, after := side(-1), side(1)
+	switch {
+	case before != after:
+		return base
+	case before == bidiRTL:
+		return 1
+	}
+	return ltrLevel
+}
@@ -0,0 +1,131 @@
+package ugform
+
+import (
+	"reflect"
+	"testing"
+)
+
+// visual returns s as it appears on screen when laid out by
+// visualOrder with each rune as its own cluster
+func visual(s string, rtl bool) string {
+	var cs [][]rune
+	for _, r := range s {
+		cs = append(cs, []rune{r})
+	}
+	var out []rune
+	for _, i := range visualOrder(cs, rtl) {
+		out = append(out, cs[i]...)
+	}
+	return string(out)
+}
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		rtl  bool
+		want string
+	}{
+		{"ltr only", "abc def", false, "abc def"},
+		{"rtl only", "אבג", true, "גבא"},
+		{"rtl run in ltr base", "ab אבג cd", false, "ab גבא cd"},
+		{"ltr run in rtl base", "אב cd גד", true, "דג cd בא"},
+		{"numbers after rtl", "אב 12", false, "12 בא"},
+		{"numbers after rtl in rtl base", "אב 12", true, "12 בא"},
+		{"numbers after ltr", "ab 12", true, "ab 12"},
+		{"numbers inside rtl", "א12ב", false, "ב12א"},
+		{"neutrals between different directions", "אב - cd", false, "בא - cd"},
+		{"neutrals between different directions in rtl base", "ab - אב", true, "בא - ab"},
+		{"neutrals between rtl", "א - ב", false, "ב - א"},
+		{"neutrals between ltr in rtl base", "ab - cd", true, "ab - cd"},
+		{"trailing whitespace", "abc אב  ", false, "abc בא  "},
+		{"trailing whitespace in rtl base", "אב ab ", true, " ab בא"},
+	}
+	for _, tt := range tests {
+		if got := visual(tt.text, tt.rtl); got != tt.want {
+			t.Errorf("%s: visualOrder(%q) shows %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNeutralLevel(t *testing.T) {
+	const (
+		n = bidiNeutral
+		l = bidiLTR
+		r = bidiRTL
+		d = bidiNumber
+	)
+	tests := []struct {
+		dirs           []bidiType
+		i              int
+		base, ltrLevel int
+		want           int
+	}{
+		{[]bidiType{l, n, l}, 1, 0, 0, 0},
+		{[]bidiType{r, n, r}, 1, 0, 0, 1},
+		{[]bidiType{l, n, r}, 1, 0, 0, 0},
+		{[]bidiType{r, n, l}, 1, 1, 2, 1},
+		{[]bidiType{l, n, l}, 1, 1, 2, 2},
+		// numbers count as right to left
+		{[]bidiType{r, n, d}, 1, 0, 0, 1},
+		{[]bidiType{d, n, l}, 1, 0, 0, 0},
+		// neutrals are skipped to find the strong text
+		{[]bidiType{r, n, n, n, r}, 2, 0, 0, 1},
+		// the edges of the line count as the base direction
+		{[]bidiType{n, r}, 0, 0, 0, 0},
+		{[]bidiType{n, r}, 0, 1, 2, 1},
+		{[]bidiType{l, n}, 1, 0, 0, 0},
+		{[]bidiType{l, n}, 1, 1, 2, 1},
+	}
+	for _, tt := range tests {
+		if got := neutralLevel(tt.dirs, tt.i, tt.base, tt.ltrLevel); got != tt.want {
+			t.Errorf("neutralLevel(%v, %d, base %d) = %d, want %d", tt.dirs, tt.i, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestMaskedLayoutKeepsOrder(t *testing.T) {
+	_, tb := newTestBox(t, &AddTextBoxInput{Name: "p", Width: 10, Password: true, DefaultValue: "אב cd"})
+	gs, _ := tb.lineLayout(0)
+	var pos []int
+	for _, g := range gs {
+		pos = append(pos, g.pos)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(pos, want) {
+		t.Errorf("masked layout order = %v, want %v", pos, want)
+	}
+}
+
+func TestMoveVisualEdges(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		dir   Direction
+		start int
+		moves []int
+		want  []int
+	}{
+		// ab גבא: the Hebrew is shown reversed after "ab "
+		{"left at start of ltr", "ab אבג", DirectionLTR, 0, []int{-1}, []int{0}},
+		{"right at end of ltr", "ab אבג", DirectionLTR, 6, []int{1}, []int{6}},
+		{"left from end into rtl run", "ab אבג", DirectionLTR, 6, []int{-1, -1, -1, -1}, []int{3, 4, 5, 2}},
+		{"right out of rtl run", "ab אבג", DirectionLTR, 2, []int{1, 1, 1, 1}, []int{5, 4, 3, 6}},
+		// גבא is right aligned with the start on the right
+		{"right at start of rtl", "אבג", DirectionRTL, 0, []int{1}, []int{0}},
+		{"left at end of rtl", "אבג", DirectionRTL, 3, []int{-1}, []int{3}},
+		{"left through rtl", "אבג", DirectionRTL, 0, []int{-1, -1, -1}, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 10, Direction: tt.dir, DefaultValue: tt.text})
+		f.Start()
+		tb.moveCursor(tt.start)
+		var got []int
+		for _, m := range tt.moves {
+			tb.moveVisual(m)
+			got = append(got, tb.ci)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: moves %v from %d went to %v, want %v", tt.name, tt.moves, tt.start, got, tt.want)
+		}
+	}
+}
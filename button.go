@@ -0,0 +1,224 @@
+package ugform
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ButtonAction determines what happens when a button is pressed
+type ButtonAction int
+
+const (
+	// ButtonCustom only runs the button's OnPress callback
+	ButtonCustom ButtonAction = iota
+	// ButtonSubmit submits the form just like ActionSubmit
+	ButtonSubmit
+	// ButtonCancel leaves the form just like ActionCancel
+	ButtonCancel
+)
+
+type button struct {
+	tabOrder int
+	name     string
+	label    string
+	action   ButtonAction
+	onPress  func(f *Form)
+	px, py   int          // button position
+	cs, bs   tcell.Style  // focused and button style
+	s        tcell.Screen // need direct access to screen
+	f        *Form        // form to notify when pressed
+	focused  bool
+}
+
+func (b *button) getName() string {
+	return b.name
+}
+
+func (b *button) getTabOrder() int {
+	return b.tabOrder
+}
+
+// getDescription returns the button's label
+func (b *button) getDescription() string {
+	return b.label
+}
+
+// value is always empty since buttons hold no data
+func (b *button) value() string {
+	return ""
+}
+
+// setValue is not supported since buttons hold no data
+func (b *button) setValue(v string) error {
+	return errors.New("buttons have no value")
+}
+
+func (b *button) defaultValue() string {
+	return ""
+}
+
+func (b *button) clear() {}
+
+// redraw draws the button
+func (b *button) redraw() {
+	b.draw()
+}
+
+// shift moves the button by x and y
+func (b *button) shift(x, y int) {
+	b.px += x
+	b.py += y
+}
+
+// draw draws the button's label surrounded by brackets using
+// the focused style if the button currently has focus
+func (b *button) draw() {
+	style := b.bs
+	if b.focused {
+		style = b.cs
+	}
+	drawString(b.s, b.px, b.py, "[ "+b.label+" ]", -1, style)
+	b.s.Show()
+}
+
+// start draws the button
+func (b *button) start() {
+	b.draw()
+}
+
+// showCursor draws the button in its focused style
+func (b *button) showCursor() {
+	b.focused = true
+	b.draw()
+}
+
+// hideCursor draws the button in its normal style
+func (b *button) hideCursor() {
+	b.focused = false
+	b.draw()
+}
+
+// press runs the button's callback if any and then lets the
+// form know whether it should submit or cancel
+func (b *button) press() {
+	log("Debug", "button pressed", "button", b.name)
+	if b.onPress != nil {
+		b.onPress(b.f)
+	}
+	switch b.action {
+	case ButtonSubmit:
+		b.f.pending = requestSubmit
+	case ButtonCancel:
+		b.f.pending = requestCancel
+	}
+}
+
+// handleKey presses the button on Enter or Space and reports
+// whether or not the key was consumed
+func (b *button) handleKey(ev *tcell.EventKey, a Action) bool {
+	switch {
+	case pressed(ev, tcell.KeyEnter):
+	case typed(ev) && ev.Rune() == ' ':
+	default:
+		return false
+	}
+	b.press()
+	return true
+}
+
+// bounds returns the area of the screen covered by the
+// button's label and brackets
+func (b *button) bounds() (x, y, w, h int) {
+	return b.px, b.py, textWidth(b.label) + 4, 1
+}
+
+// contains reports whether x, y is on the button
+func (b *button) contains(x, y int) bool {
+	bx, by, bw, bh := b.bounds()
+	return inside(x, y, bx, by, bw, bh)
+}
+
+// click presses the button
+func (b *button) click(x, y int) {
+	b.press()
+}
+
+func (b *button) wheel(n int) bool {
+	return false
+}
+
+func (b *button) paste(text []rune) {}
+
+// labelSize is always zero since a button's label is drawn
+// inside its brackets
+func (b *button) labelSize() (left, right, above int) {
+	return 0, 0, 0
+}
+
+func (b *button) setLabelAbove(above bool) {}
+
+// AddButton is a constructor for adding a new button to the form.
+// Buttons take part in the tab order like any other component but
+// are left out when collecting results.
+func (f *Form) AddButton(in *AddButtonInput) (err error) {
+	b := button{}
+	b.name = in.Name
+	b.label = in.Label
+	b.action = in.Action
+	b.onPress = in.OnPress
+	b.tabOrder = in.TabOrder
+	f.tabOrder[b.tabOrder] = b.name
+	b.s = f.s
+	b.f = f
+	b.px = in.PositionX
+	b.py = in.PositionY
+	b.cs = in.StyleFocus
+	b.bs = in.StyleButton
+	f.components[b.name] = &b
+	if in.HasFocus {
+		f.focus = &b
+	}
+	return err
+}
+
+// AddButtonInput provides all the input parameters for the
+// AddButton constructor
+type AddButtonInput struct {
+	// Name of the button. Must be unique among the form's
+	// components even though buttons are not collected.
+	Name string
+
+	// Label is the text displayed inside the button
+	Label string
+
+	// Action is what the button does when pressed, either
+	// ButtonSubmit, ButtonCancel, or ButtonCustom
+	Action ButtonAction
+
+	// OnPress is called with the form whenever the button is
+	// pressed before any submit or cancel takes place. It is
+	// optional for submit and cancel buttons.
+	OnPress func(f *Form)
+
+	// TabOrder of the button for this form. Must be unique
+	// within a form or unstable tab behavior could result
+	TabOrder int
+
+	// PositionX is the x-axis position of the button
+	PositionX int
+
+	// PositionY is the y-axis position of the button
+	PositionY int
+
+	// tcell Style to use for the button when it has focus
+	StyleFocus tcell.Style
+
+	// tcell Style to use for the button when it does not
+	// have focus
+	StyleButton tcell.Style
+
+	// Whether or not this button has focus when the form's
+	// polling method is activated.
+	HasFocus bool
+}
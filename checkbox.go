@@ -0,0 +1,240 @@
+package ugform
+
+import (
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type checkBox struct {
+	tabOrder          int
+	name, description string
+	checked, def      bool
+	px, py            int          // checkBox position
+	cs, bs, ds        tcell.Style  // focused, box, and description style
+	s                 tcell.Screen // need direct access to screen
+	showDescription   bool
+	labelAbove        bool // draw the description above rather than beside
+	focused           bool
+	errorLine
+}
+
+func (c *checkBox) getName() string {
+	return c.name
+}
+
+func (c *checkBox) getTabOrder() int {
+	return c.tabOrder
+}
+
+func (c *checkBox) getDescription() string {
+	return c.description
+}
+
+// value returns "true" or "false" depending on whether
+// or not the checkBox is checked
+func (c *checkBox) value() string {
+	return strconv.FormatBool(c.checked)
+}
+
+// setValue checks or unchecks the checkBox from any value
+// accepted by strconv.ParseBool
+func (c *checkBox) setValue(v string) error {
+	checked, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	c.checked = checked
+	return nil
+}
+
+func (c *checkBox) defaultValue() string {
+	return strconv.FormatBool(c.def)
+}
+
+// clear unchecks the checkBox
+func (c *checkBox) clear() {
+	c.checked = false
+}
+
+// redraw draws the checkBox
+func (c *checkBox) redraw() {
+	c.draw()
+}
+
+// shift moves the checkBox by x and y
+func (c *checkBox) shift(x, y int) {
+	c.px += x
+	c.py += y
+}
+
+// draw draws the [x] or [ ] glyph using the focused
+// style if the checkBox currently has focus
+func (c *checkBox) draw() {
+	style := c.bs
+	if c.focused {
+		style = c.cs
+	}
+	mark := csr("")
+	if c.checked {
+		mark = csr("x")
+	}
+	c.s.SetContent(c.px, c.py, csr("["), nil, style)
+	c.s.SetContent(c.px+1, c.py, mark, nil, style)
+	c.s.SetContent(c.px+2, c.py, csr("]"), nil, style)
+	c.s.Show()
+}
+
+// start draws the checkBox and its description
+func (c *checkBox) start() {
+	if c.showDescription {
+		drawDescription(c.s, c.px, c.py, c.description, c.labelAbove, c.ds)
+	}
+	c.draw()
+}
+
+// showCursor draws the checkBox in its focused style
+func (c *checkBox) showCursor() {
+	c.focused = true
+	c.draw()
+}
+
+// hideCursor draws the checkBox in its normal style
+func (c *checkBox) hideCursor() {
+	c.focused = false
+	c.draw()
+}
+
+// toggle flips the checked state of the checkBox
+func (c *checkBox) toggle() {
+	c.checked = !c.checked
+	c.draw()
+}
+
+// handleKey toggles the checkBox when Space is pressed and
+// reports whether or not the key was consumed
+func (c *checkBox) handleKey(ev *tcell.EventKey, a Action) bool {
+	if typed(ev) && ev.Rune() == ' ' {
+		c.toggle()
+		return true
+	}
+	return false
+}
+
+// bounds returns the area of the screen covered by the glyph
+func (c *checkBox) bounds() (x, y, w, h int) {
+	return c.px, c.py, 3, 1
+}
+
+// contains reports whether x, y is on the glyph
+func (c *checkBox) contains(x, y int) bool {
+	return inside(x, y, c.px, c.py, 3, 1)
+}
+
+// click toggles the checkBox
+func (c *checkBox) click(x, y int) {
+	c.toggle()
+}
+
+func (c *checkBox) wheel(n int) bool {
+	return false
+}
+
+// labelSize returns the space taken up by the checkBox's
+// description
+func (c *checkBox) labelSize() (left, right, above int) {
+	left, above = descriptionSize(c.description, c.showDescription, c.labelAbove)
+	return left, 0, above
+}
+
+func (c *checkBox) setLabelAbove(above bool) {
+	c.labelAbove = above
+}
+
+func (c *checkBox) paste(text []rune) {}
+
+// AddCheckBox is a constructor for adding a new checkBox to the
+// form. Checkboxes share the tab order of the form with all other
+// components so TabOrder must be unique across all of them.
+func (f *Form) AddCheckBox(in *AddCheckBoxInput) (err error) {
+	c := checkBox{}
+	c.name = in.Name
+	c.description = in.Description
+	c.def = in.DefaultValue
+	c.checked = c.def
+	c.tabOrder = in.TabOrder
+	f.tabOrder[c.tabOrder] = c.name
+	c.s = f.s
+	c.px = in.PositionX
+	c.py = in.PositionY
+	c.cs = in.StyleFocus
+	c.bs = in.StyleBox
+	c.ds = in.StyleDescription
+	c.showDescription = in.ShowDescription
+	c.es = in.StyleError
+	f.components[c.name] = &c
+	if in.HasFocus {
+		f.focus = &c
+	}
+	return err
+}
+
+// AddCheckBoxInput provides all the input parameters for the
+// AddCheckBox constructor
+type AddCheckBoxInput struct {
+	// Name of the checkbox which will be included
+	// when collecting results
+	Name string
+
+	// Description of the checkbox which will be displayed
+	// to the left of the checkbox just like a textbox's
+	Description string
+
+	// DefaultValue indicates whether or not the checkbox
+	// starts out checked
+	DefaultValue bool
+
+	// TabOrder of the checkbox for this form. Must be unique
+	// within a form or unstable tab behavior could result
+	TabOrder int
+
+	// PositionX is the x-axis position of the checkbox
+	PositionX int
+
+	// PositionY is the y-axis position of the checkbox
+	PositionY int
+
+	// tcell Style to use for the [x] glyph when the
+	// checkbox has focus
+	StyleFocus tcell.Style
+
+	// tcell Style to use for the [x] glyph when the
+	// checkbox does not have focus
+	StyleBox tcell.Style
+
+	// tcell Style for the checkbox's description.
+	StyleDescription tcell.Style
+
+	// Whether or not to show the description to the user.
+	ShowDescription bool
+
+	// tcell Style for error messages attached by form level
+	// validators which are drawn underneath.
+	StyleError tcell.Style
+
+	// Whether or not this checkbox has focus when the form's
+	// polling method is activated.
+	HasFocus bool
+}
+
+// CollectBools returns a map of the name and checked state of
+// all of the form's checkboxes.
+func (f *Form) CollectBools() (results map[string]bool) {
+	results = make(map[string]bool)
+	for _, v := range f.components {
+		if c, ok := v.(*checkBox); ok {
+			results[c.name] = c.checked
+		}
+	}
+	return results
+}
@@ -0,0 +1,30 @@
+package ugform
+
+// Clipboard is where cut and copied text goes and where pasted
+// text comes from. Every form starts out with its own
+// MemoryClipboard but an application can share one between
+// forms or plug in something that talks to the system
+// clipboard or the terminal through OSC 52.
+type Clipboard interface {
+	// Copy puts text on the clipboard
+	Copy(text string) error
+	// Paste returns the text on the clipboard
+	Paste() (string, error)
+}
+
+// MemoryClipboard is a Clipboard that keeps its text in memory
+// which is enough for moving values between fields of a form
+type MemoryClipboard struct {
+	text string
+}
+
+// Copy puts text on the clipboard
+func (m *MemoryClipboard) Copy(text string) error {
+	m.text = text
+	return nil
+}
+
+// Paste returns the text on the clipboard
+func (m *MemoryClipboard) Paste() (string, error) {
+	return m.text, nil
+}
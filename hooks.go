@@ -0,0 +1,96 @@
+package ugform
+
+// fieldHooks holds the optional callbacks for a single field
+type fieldHooks struct {
+	onChange func(f *Form, oldValue, newValue string)
+	onFocus  func(f *Form)
+	onBlur   func(f *Form)
+}
+
+// hooksFor returns the hooks of the named field creating them
+// if needed or an error if the form has no such field
+func (f *Form) hooksFor(name string) (*fieldHooks, error) {
+	if _, err := f.component(name); err != nil {
+		return nil, err
+	}
+	h, ok := f.hooks[name]
+	if !ok {
+		h = &fieldHooks{}
+		f.hooks[name] = h
+	}
+	return h, nil
+}
+
+// OnChange sets a callback which runs inside Poll whenever the
+// user changes the value of the named field. Changes made with
+// SetValue, Reset, or Clear do not trigger it.
+func (f *Form) OnChange(name string, fn func(f *Form, oldValue, newValue string)) error {
+	h, err := f.hooksFor(name)
+	if err != nil {
+		return err
+	}
+	h.onChange = fn
+	return nil
+}
+
+// OnFocus sets a callback which runs inside Poll whenever the
+// named field gains focus including when polling starts
+func (f *Form) OnFocus(name string, fn func(f *Form)) error {
+	h, err := f.hooksFor(name)
+	if err != nil {
+		return err
+	}
+	h.onFocus = fn
+	return nil
+}
+
+// OnBlur sets a callback which runs inside Poll whenever the
+// named field loses focus including when polling stops
+func (f *Form) OnBlur(name string, fn func(f *Form)) error {
+	h, err := f.hooksFor(name)
+	if err != nil {
+		return err
+	}
+	h.onBlur = fn
+	return nil
+}
+
+// OnSubmit sets a callback which runs inside Poll once the form
+// has passed validation and just before the form's name is sent
+// to the submit channel
+func (f *Form) OnSubmit(fn func(f *Form)) {
+	f.onSubmit = fn
+}
+
+// OnCancel sets a callback which runs inside Poll when the user
+// cancels the form with Escape or a cancel button. It does not
+// run when polling stops because the context was cancelled.
+func (f *Form) OnCancel(fn func(f *Form)) {
+	f.onCancel = fn
+}
+
+// changed runs the OnChange hook of c if its value is no
+// longer oldValue
+func (f *Form) changed(c component, oldValue string) {
+	h, ok := f.hooks[c.getName()]
+	if !ok || h.onChange == nil {
+		return
+	}
+	if newValue := c.value(); newValue != oldValue {
+		h.onChange(f, oldValue, newValue)
+	}
+}
+
+// focused runs the OnFocus hook of c
+func (f *Form) focused(c component) {
+	if h, ok := f.hooks[c.getName()]; ok && h.onFocus != nil {
+		h.onFocus(f)
+	}
+}
+
+// blurred runs the OnBlur hook of c
+func (f *Form) blurred(c component) {
+	if h, ok := f.hooks[c.getName()]; ok && h.onBlur != nil {
+		h.onBlur(f)
+	}
+}
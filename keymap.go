@@ -0,0 +1,244 @@
+package ugform
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Action is something the user can do with a keystroke. A
+// Keymap translates keystrokes into Actions which the focused
+// component gets the first chance to handle before the form.
+type Action int
+
+const (
+	// ActionNone means the keystroke isn't bound to anything.
+	// Unbound runes are typed into textboxes.
+	ActionNone Action = iota
+	// ActionNextField moves focus to the next field
+	ActionNextField
+	// ActionPrevField moves focus to the previous field
+	ActionPrevField
+	// ActionSubmit validates and submits the form
+	ActionSubmit
+	// ActionCancel leaves the form without submitting
+	ActionCancel
+	// ActionLeft moves the cursor or selection left
+	ActionLeft
+	// ActionRight moves the cursor or selection right
+	ActionRight
+	// ActionUp moves the cursor or selection up
+	ActionUp
+	// ActionDown moves the cursor or selection down
+	ActionDown
+	// ActionPageUp moves the cursor or selection up a page
+	ActionPageUp
+	// ActionPageDown moves the cursor or selection down a page
+	ActionPageDown
+	// ActionHome moves the cursor to the start of the line
+	ActionHome
+	// ActionEnd moves the cursor to the end of the line
+	ActionEnd
+	// ActionBackspace deletes the character before the cursor
+	ActionBackspace
+	// ActionDelete deletes the character under the cursor
+	ActionDelete
+	// ActionDeleteWord deletes the word before the cursor
+	ActionDeleteWord
+	// ActionClearField empties the focused textbox
+	ActionClearField
+	// ActionUndo reverts the focused textbox's last edit
+	ActionUndo
+	// ActionRedo reapplies the focused textbox's last undone edit
+	ActionRedo
+	// ActionSelectLeft extends the selection one rune left
+	ActionSelectLeft
+	// ActionSelectRight extends the selection one rune right
+	ActionSelectRight
+	// ActionSelectHome extends the selection to the start of the line
+	ActionSelectHome
+	// ActionSelectEnd extends the selection to the end of the line
+	ActionSelectEnd
+	// ActionSelectAll selects the whole of the focused textbox
+	ActionSelectAll
+	// ActionCut moves the selection to the form's Clipboard
+	ActionCut
+	// ActionCopy copies the selection to the form's Clipboard
+	ActionCopy
+	// ActionPaste inserts the text on the form's Clipboard
+	ActionPaste
+	// ActionDeleteNextWord deletes the word after the cursor
+	ActionDeleteNextWord
+	// ActionKillToStart deletes from the cursor to the start
+	// of the line
+	ActionKillToStart
+	// ActionKillToEnd deletes from the cursor to the end of
+	// the line
+	ActionKillToEnd
+	// ActionYank inserts the text most recently deleted by a
+	// word or line deletion
+	ActionYank
+	// ActionWordLeft moves the cursor to the start of the
+	// previous word
+	ActionWordLeft
+	// ActionWordRight moves the cursor to the end of the
+	// next word
+	ActionWordRight
+)
+
+// keyCombo is a single keystroke including its modifiers
+type keyCombo struct {
+	key tcell.Key
+	r   rune
+	mod tcell.ModMask
+}
+
+// newKeyCombo normalizes a keystroke so that control keys
+// match whether or not the terminal reported ModCtrl with them
+func newKeyCombo(key tcell.Key, r rune, mod tcell.ModMask) keyCombo {
+	if key != tcell.KeyRune {
+		r = 0
+	}
+	if key <= tcell.KeyUS || key == tcell.KeyDEL {
+		mod &^= tcell.ModCtrl
+	}
+	return keyCombo{key, r, mod}
+}
+
+// Keymap maps keystrokes to Actions. Every form starts with its
+// own copy of DefaultKeymap in its Keymap field which can be
+// changed or replaced before polling.
+type Keymap struct {
+	bindings map[keyCombo]Action
+}
+
+// NewKeymap returns an empty Keymap
+func NewKeymap() *Keymap {
+	return &Keymap{bindings: make(map[keyCombo]Action)}
+}
+
+// DefaultKeymap returns a new Keymap with the standard bindings:
+// Tab and Backtab move between fields, Enter submits, Escape
+// cancels, the arrow, Home, End, and paging keys move the cursor
+// and select with Shift held, Ctrl-E goes to the end of the line,
+// Ctrl-W deletes the previous word, Ctrl-U clears the field,
+// Ctrl-Z and Ctrl-Y undo and redo, Ctrl-A selects everything,
+// and Ctrl-X, Ctrl-C, and Ctrl-V cut, copy, and paste.
+func DefaultKeymap() *Keymap {
+	k := NewKeymap()
+	k.BindKey(tcell.KeyTab, tcell.ModNone, ActionNextField)
+	k.BindKey(tcell.KeyBacktab, tcell.ModNone, ActionPrevField)
+	k.BindKey(tcell.KeyBacktab, tcell.ModShift, ActionPrevField)
+	k.BindKey(tcell.KeyEnter, tcell.ModNone, ActionSubmit)
+	k.BindKey(tcell.KeyEscape, tcell.ModNone, ActionCancel)
+	k.BindKey(tcell.KeyLeft, tcell.ModNone, ActionLeft)
+	k.BindKey(tcell.KeyRight, tcell.ModNone, ActionRight)
+	k.BindKey(tcell.KeyUp, tcell.ModNone, ActionUp)
+	k.BindKey(tcell.KeyDown, tcell.ModNone, ActionDown)
+	k.BindKey(tcell.KeyPgUp, tcell.ModNone, ActionPageUp)
+	k.BindKey(tcell.KeyPgDn, tcell.ModNone, ActionPageDown)
+	k.BindKey(tcell.KeyHome, tcell.ModNone, ActionHome)
+	k.BindKey(tcell.KeyEnd, tcell.ModNone, ActionEnd)
+	k.BindKey(tcell.KeyCtrlE, tcell.ModNone, ActionEnd)
+	k.BindKey(tcell.KeyBackspace, tcell.ModNone, ActionBackspace)
+	k.BindKey(tcell.KeyBackspace2, tcell.ModNone, ActionBackspace)
+	k.BindKey(tcell.KeyDelete, tcell.ModNone, ActionDelete)
+	k.BindKey(tcell.KeyCtrlW, tcell.ModNone, ActionDeleteWord)
+	k.BindKey(tcell.KeyCtrlU, tcell.ModNone, ActionClearField)
+	k.BindKey(tcell.KeyCtrlZ, tcell.ModNone, ActionUndo)
+	k.BindKey(tcell.KeyCtrlY, tcell.ModNone, ActionRedo)
+	k.BindKey(tcell.KeyLeft, tcell.ModShift, ActionSelectLeft)
+	k.BindKey(tcell.KeyRight, tcell.ModShift, ActionSelectRight)
+	k.BindKey(tcell.KeyHome, tcell.ModShift, ActionSelectHome)
+	k.BindKey(tcell.KeyEnd, tcell.ModShift, ActionSelectEnd)
+	k.BindKey(tcell.KeyCtrlA, tcell.ModNone, ActionSelectAll)
+	k.BindKey(tcell.KeyCtrlX, tcell.ModNone, ActionCut)
+	k.BindKey(tcell.KeyCtrlC, tcell.ModNone, ActionCopy)
+	k.BindKey(tcell.KeyCtrlV, tcell.ModNone, ActionPaste)
+	return k
+}
+
+// ReadlineKeymap returns a new Keymap with the default bindings
+// changed to behave like readline and emacs: Ctrl-A goes to the
+// start of the line, Ctrl-W and Alt-Backspace delete the
+// previous word, Alt-D deletes the next word, Ctrl-U and Ctrl-K
+// delete to the start and end of the line, Alt-B and Alt-F move
+// by word, and Ctrl-Y yanks back the last deleted text. Select
+// all and redo lose their Ctrl-A and Ctrl-Y bindings. Turn it on
+// for a form by setting the form's Keymap.
+func ReadlineKeymap() *Keymap {
+	k := DefaultKeymap()
+	k.BindKey(tcell.KeyCtrlA, tcell.ModNone, ActionHome)
+	k.BindKey(tcell.KeyBackspace, tcell.ModAlt, ActionDeleteWord)
+	k.BindKey(tcell.KeyBackspace2, tcell.ModAlt, ActionDeleteWord)
+	k.BindRune('d', tcell.ModAlt, ActionDeleteNextWord)
+	k.BindKey(tcell.KeyCtrlU, tcell.ModNone, ActionKillToStart)
+	k.BindKey(tcell.KeyCtrlK, tcell.ModNone, ActionKillToEnd)
+	k.BindRune('b', tcell.ModAlt, ActionWordLeft)
+	k.BindRune('f', tcell.ModAlt, ActionWordRight)
+	k.BindKey(tcell.KeyCtrlY, tcell.ModNone, ActionYank)
+	return k
+}
+
+// Clone returns a copy of the Keymap which can be changed
+// without affecting the original
+func (k *Keymap) Clone() *Keymap {
+	c := NewKeymap()
+	for combo, a := range k.bindings {
+		c.bindings[combo] = a
+	}
+	return c
+}
+
+// BindKey binds a special key such as tcell.KeyCtrlS or
+// tcell.KeyUp pressed with the given modifiers to action a
+func (k *Keymap) BindKey(key tcell.Key, mod tcell.ModMask, a Action) {
+	k.bindings[newKeyCombo(key, 0, mod)] = a
+}
+
+// BindRune binds a rune pressed with the given modifiers such
+// as tcell.ModAlt to action a. Binding runes without modifiers
+// stops them from being typed into textboxes.
+func (k *Keymap) BindRune(r rune, mod tcell.ModMask, a Action) {
+	k.bindings[newKeyCombo(tcell.KeyRune, r, mod)] = a
+}
+
+// UnbindKey removes the binding for a special key
+func (k *Keymap) UnbindKey(key tcell.Key, mod tcell.ModMask) {
+	delete(k.bindings, newKeyCombo(key, 0, mod))
+}
+
+// UnbindRune removes the binding for a rune
+func (k *Keymap) UnbindRune(r rune, mod tcell.ModMask) {
+	delete(k.bindings, newKeyCombo(tcell.KeyRune, r, mod))
+}
+
+// UnbindAction removes every binding for action a, handy
+// before binding the action to a different key
+func (k *Keymap) UnbindAction(a Action) {
+	for combo, bound := range k.bindings {
+		if bound == a {
+			delete(k.bindings, combo)
+		}
+	}
+}
+
+// Lookup returns the Action bound to the keystroke of ev or
+// ActionNone if there isn't one
+func (k *Keymap) Lookup(ev *tcell.EventKey) Action {
+	if k == nil {
+		return ActionNone
+	}
+	return k.bindings[newKeyCombo(ev.Key(), ev.Rune(), ev.Modifiers())]
+}
+
+// typed reports whether ev is a rune typed without Ctrl or Alt
+// which should be inserted as text when it isn't bound
+func typed(ev *tcell.EventKey) bool {
+	return ev.Key() == tcell.KeyRune && ev.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) == 0
+}
+
+// pressed reports whether ev is key pressed without modifiers
+// for components that react to particular keys regardless of
+// the Keymap such as Enter on a button
+func pressed(ev *tcell.EventKey, key tcell.Key) bool {
+	return ev.Key() == key && ev.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModShift) == 0
+}
@@ -0,0 +1,121 @@
+package ugform
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func lookup(k *Keymap, key tcell.Key, r rune, mod tcell.ModMask) Action {
+	return k.Lookup(tcell.NewEventKey(key, r, mod))
+}
+
+func TestDefaultKeymap(t *testing.T) {
+	k := DefaultKeymap()
+	tests := []struct {
+		key  tcell.Key
+		r    rune
+		mod  tcell.ModMask
+		want Action
+	}{
+		{tcell.KeyTab, 0, tcell.ModNone, ActionNextField},
+		{tcell.KeyBacktab, 0, tcell.ModNone, ActionPrevField},
+		{tcell.KeyBacktab, 0, tcell.ModShift, ActionPrevField},
+		{tcell.KeyEnter, 0, tcell.ModNone, ActionSubmit},
+		{tcell.KeyEscape, 0, tcell.ModNone, ActionCancel},
+		{tcell.KeyLeft, 0, tcell.ModNone, ActionLeft},
+		{tcell.KeyLeft, 0, tcell.ModShift, ActionSelectLeft},
+		{tcell.KeyCtrlZ, 0, tcell.ModCtrl, ActionUndo},
+		{tcell.KeyCtrlA, 0, tcell.ModCtrl, ActionSelectAll},
+		{tcell.KeyRune, 'a', tcell.ModNone, ActionNone},
+		{tcell.KeyLeft, 0, tcell.ModAlt, ActionNone},
+	}
+	for _, tt := range tests {
+		if got := lookup(k, tt.key, tt.r, tt.mod); got != tt.want {
+			t.Errorf("Lookup(%s) = %v, want %v", tcell.NewEventKey(tt.key, tt.r, tt.mod).Name(), got, tt.want)
+		}
+	}
+}
+
+func TestKeyComboNormalization(t *testing.T) {
+	k := NewKeymap()
+	k.BindKey(tcell.KeyCtrlS, tcell.ModNone, ActionSubmit)
+	k.BindKey(tcell.KeyEnter, tcell.ModNone, ActionNextField)
+	// terminals differ on whether control keys carry ModCtrl
+	for _, mod := range []tcell.ModMask{tcell.ModNone, tcell.ModCtrl} {
+		if got := lookup(k, tcell.KeyCtrlS, 0, mod); got != ActionSubmit {
+			t.Errorf("Ctrl-S with mod %v = %v, want ActionSubmit", mod, got)
+		}
+	}
+	// runes reported with special keys are ignored
+	if got := k.Lookup(tcell.NewEventKey(tcell.KeyEnter, '\r', tcell.ModNone)); got != ActionNextField {
+		t.Errorf("Enter with a rune = %v, want ActionNextField", got)
+	}
+	// Ctrl stays significant for keys other than control codes
+	k.BindKey(tcell.KeyRight, tcell.ModCtrl, ActionWordRight)
+	if got := lookup(k, tcell.KeyRight, 0, tcell.ModNone); got != ActionNone {
+		t.Errorf("Right = %v, want ActionNone", got)
+	}
+	if got := newKeyCombo(tcell.KeyRune, 'x', tcell.ModAlt); got.r != 'x' || got.mod != tcell.ModAlt {
+		t.Errorf("newKeyCombo(Alt-x) = %+v", got)
+	}
+}
+
+func TestKeymapBindUnbind(t *testing.T) {
+	k := DefaultKeymap()
+	c := k.Clone()
+	c.UnbindAction(ActionSubmit)
+	c.BindKey(tcell.KeyCtrlS, tcell.ModNone, ActionSubmit)
+	c.BindRune('q', tcell.ModAlt, ActionCancel)
+	c.UnbindKey(tcell.KeyEscape, tcell.ModNone)
+	if got := lookup(c, tcell.KeyEnter, 0, tcell.ModNone); got != ActionNone {
+		t.Errorf("clone Enter = %v after UnbindAction", got)
+	}
+	if got := lookup(c, tcell.KeyCtrlS, 0, tcell.ModCtrl); got != ActionSubmit {
+		t.Errorf("clone Ctrl-S = %v", got)
+	}
+	if got := lookup(c, tcell.KeyRune, 'q', tcell.ModAlt); got != ActionCancel {
+		t.Errorf("clone Alt-q = %v", got)
+	}
+	if got := lookup(c, tcell.KeyEscape, 0, tcell.ModNone); got != ActionNone {
+		t.Errorf("clone Escape = %v after UnbindKey", got)
+	}
+	c.UnbindRune('q', tcell.ModAlt)
+	if got := lookup(c, tcell.KeyRune, 'q', tcell.ModAlt); got != ActionNone {
+		t.Errorf("clone Alt-q = %v after UnbindRune", got)
+	}
+	// the original is untouched
+	if got := lookup(k, tcell.KeyEnter, 0, tcell.ModNone); got != ActionSubmit {
+		t.Errorf("original Enter = %v", got)
+	}
+	var nilmap *Keymap
+	if got := lookup(nilmap, tcell.KeyEnter, 0, tcell.ModNone); got != ActionNone {
+		t.Errorf("nil Keymap Lookup = %v", got)
+	}
+}
+
+func TestReadlineKeymap(t *testing.T) {
+	k := ReadlineKeymap()
+	tests := []struct {
+		key  tcell.Key
+		r    rune
+		mod  tcell.ModMask
+		want Action
+	}{
+		{tcell.KeyCtrlA, 0, tcell.ModCtrl, ActionHome},
+		{tcell.KeyCtrlE, 0, tcell.ModCtrl, ActionEnd},
+		{tcell.KeyCtrlK, 0, tcell.ModCtrl, ActionKillToEnd},
+		{tcell.KeyCtrlU, 0, tcell.ModCtrl, ActionKillToStart},
+		{tcell.KeyCtrlY, 0, tcell.ModCtrl, ActionYank},
+		{tcell.KeyBackspace2, 0, tcell.ModAlt, ActionDeleteWord},
+		{tcell.KeyRune, 'b', tcell.ModAlt, ActionWordLeft},
+		{tcell.KeyRune, 'f', tcell.ModAlt, ActionWordRight},
+		{tcell.KeyRune, 'd', tcell.ModAlt, ActionDeleteNextWord},
+		{tcell.KeyRune, 'd', tcell.ModNone, ActionNone},
+	}
+	for _, tt := range tests {
+		if got := lookup(k, tt.key, tt.r, tt.mod); got != tt.want {
+			t.Errorf("Lookup(%s) = %v, want %v", tcell.NewEventKey(tt.key, tt.r, tt.mod).Name(), got, tt.want)
+		}
+	}
+}
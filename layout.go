@@ -0,0 +1,238 @@
+package ugform
+
+// LayoutMode is how SetLayout arranges a form's components
+type LayoutMode int
+
+const (
+	// LayoutGrid lines components up in a column to the right of
+	// a column holding their descriptions. The description column
+	// is as wide as the widest description.
+	LayoutGrid LayoutMode = iota
+	// LayoutStack lines components up in a single column with
+	// each description drawn on the row above its component
+	LayoutStack
+	// LayoutRows places components side by side with their
+	// descriptions to their left, starting a new row whenever
+	// the next one would run off the right edge of the screen
+	LayoutRows
+)
+
+// Anchor is the part of the screen a laid out form sticks to
+// when the screen is resized
+type Anchor int
+
+const (
+	// AnchorTopLeft places the form X cells from the left and
+	// Y rows from the top of the screen
+	AnchorTopLeft Anchor = iota
+	// AnchorTop centers the form horizontally
+	AnchorTop
+	// AnchorTopRight places the form X cells from the right
+	AnchorTopRight
+	// AnchorLeft centers the form vertically
+	AnchorLeft
+	// AnchorCenter centers the form both ways
+	AnchorCenter
+	// AnchorRight centers the form vertically X cells from
+	// the right
+	AnchorRight
+	// AnchorBottomLeft places the form Y rows from the bottom
+	AnchorBottomLeft
+	// AnchorBottom centers the form horizontally Y rows from
+	// the bottom
+	AnchorBottom
+	// AnchorBottomRight places the form X cells from the right
+	// and Y rows from the bottom
+	AnchorBottomRight
+)
+
+// place returns the position along one axis of something size
+// long anchored to the start, middle, or end (0, 1, or 2) of a
+// screen screen long and offset away from that edge
+func place(screen, size, offset, edge int) int {
+	switch edge {
+	case 1:
+		return (screen-size)/2 + offset
+	case 2:
+		return screen - size - offset
+	}
+	return offset
+}
+
+// Layout describes how to work out the positions of every
+// component in a form from the sizes of the components and
+// their descriptions instead of using their PositionX and
+// PositionY
+type Layout struct {
+	// Mode is the arrangement of the components. Defaults to
+	// LayoutGrid.
+	Mode LayoutMode
+
+	// X is the x-axis position of the left edge of the form
+	// including its descriptions. For other anchors it is the
+	// offset from the anchored position.
+	X int
+
+	// Y is the y-axis position of the top of the form. For
+	// other anchors it is the offset from the anchored position.
+	Y int
+
+	// Anchor is where on the screen the form is placed. The
+	// form is placed again whenever the screen is resized.
+	// Defaults to AnchorTopLeft.
+	Anchor Anchor
+
+	// Spacing is the number of blank rows left between
+	// components or blank columns between them in LayoutRows.
+	// Since errors are drawn on the row underneath a component
+	// a Spacing of at least one leaves room for them.
+	Spacing int
+
+	// Center ignores X, Y, and Anchor and centers the form
+	// on screen instead. Forms that end up partly off the top
+	// or left of the screen are moved back on.
+	Center bool
+}
+
+// placement is the position worked out for a single component
+type placement struct {
+	c    component
+	x, y int
+}
+
+// SetLayout positions every component of the form according to
+// l in tab order. Components added later are not positioned
+// until SetLayout is called again or the screen is resized. A
+// form that has already been started is redrawn in its new
+// position. The summary area is left where it is.
+func (f *Form) SetLayout(l Layout) {
+	f.layout = &l
+	f.applyLayout()
+	if f.started {
+		f.repaint()
+	}
+}
+
+// applyLayout moves the components to the positions given by
+// the form's layout if it has one
+func (f *Form) applyLayout() {
+	if f.layout == nil {
+		return
+	}
+	l := f.layout
+	cs := f.ordered()
+	for _, c := range cs {
+		c.setLabelAbove(l.Mode == LayoutStack)
+	}
+	var ps []placement
+	switch l.Mode {
+	case LayoutStack:
+		ps = stackLayout(cs, l.Spacing)
+	case LayoutRows:
+		width, _ := f.s.Size()
+		ps = rowsLayout(cs, l.Spacing, width-l.X)
+	default:
+		ps = gridLayout(cs, l.Spacing)
+	}
+	anchor, dx, dy := l.Anchor, l.X, l.Y
+	if l.Center {
+		anchor, dx, dy = AnchorCenter, 0, 0
+	}
+	width, height := f.s.Size()
+	w, h := extent(ps)
+	x := place(width, w, dx, int(anchor)%3)
+	y := place(height, h, dy, int(anchor)/3)
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	for _, p := range ps {
+		cx, cy, _, _ := p.c.bounds()
+		p.c.shift(x+p.x-cx, y+p.y-cy)
+	}
+}
+
+// gridLayout places components one under another in a column
+// just right of the widest description
+func gridLayout(cs []component, spacing int) (ps []placement) {
+	col := 0
+	for _, c := range cs {
+		if left, _, _ := c.labelSize(); left > col {
+			col = left
+		}
+	}
+	y := 0
+	for _, c := range cs {
+		_, _, _, h := c.bounds()
+		ps = append(ps, placement{c, col, y})
+		y += h + spacing
+	}
+	return ps
+}
+
+// stackLayout places components one under another leaving a
+// row above each for its description
+func stackLayout(cs []component, spacing int) (ps []placement) {
+	y := 0
+	for _, c := range cs {
+		_, _, _, h := c.bounds()
+		_, _, above := c.labelSize()
+		ps = append(ps, placement{c, 0, y + above})
+		y += above + h + spacing
+	}
+	return ps
+}
+
+// rowsLayout places components left to right wrapping onto a
+// new row when the next would go past width cells
+func rowsLayout(cs []component, spacing, width int) (ps []placement) {
+	x, y, rowHeight := 0, 0, 0
+	for _, c := range cs {
+		_, _, w, h := c.bounds()
+		left, right, _ := c.labelSize()
+		if x > 0 && x+left+w+right > width {
+			x, y, rowHeight = 0, y+rowHeight+spacing, 0
+		}
+		ps = append(ps, placement{c, x + left, y})
+		x += left + w + right + spacing
+		if h > rowHeight {
+			rowHeight = h
+		}
+	}
+	return ps
+}
+
+// extent returns the width and height of the area covered by
+// placements ps and their descriptions measured from 0, 0
+func extent(ps []placement) (w, h int) {
+	for _, p := range ps {
+		_, _, cw, ch := p.c.bounds()
+		_, right, _ := p.c.labelSize()
+		if p.x+cw+right > w {
+			w = p.x + cw + right
+		}
+		if p.y+ch > h {
+			h = p.y + ch
+		}
+	}
+	return w, h
+}
+
+// repaint erases everything the form has drawn and draws every
+// component again along with any errors and the focus, keeping
+// the view within the form. The rest of the screen is left alone.
+func (f *Form) repaint() {
+	f.view.erase()
+	f.view.bar = f.overflows()
+	f.view.top = f.clampTop(f.view.top)
+	for _, c := range f.components {
+		c.start()
+		if e, ok := c.(errorShower); ok && e.shownError() != "" {
+			e.showError(e.shownError())
+		}
+	}
+	f.focus.showCursor()
+	f.view.drawScrollbar(f.contentHeight())
+}
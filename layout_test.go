@@ -0,0 +1,111 @@
+package ugform
+
+import (
+	"testing"
+)
+
+// layoutForm returns a form on a width by height screen with two
+// textboxes and a checkbox that have descriptions of different
+// lengths for testing layouts
+func layoutForm(t *testing.T, width, height int) *Form {
+	t.Helper()
+	f := NewForm(newTestScreen(t, width, height))
+	err := f.AddTextBox(&AddTextBoxInput{Name: "a", Description: "Name", ShowDescription: true, TabOrder: 0, Width: 10})
+	if err == nil {
+		err = f.AddTextBox(&AddTextBoxInput{Name: "b", Description: "Email address", ShowDescription: true, TabOrder: 1, Width: 14})
+	}
+	if err == nil {
+		err = f.AddCheckBox(&AddCheckBoxInput{Name: "c", Description: "Ok", ShowDescription: true, TabOrder: 2})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// positions returns where each of the components of a layoutForm
+// ended up
+func positions(f *Form) (ps [3][2]int) {
+	for i, name := range []string{"a", "b", "c"} {
+		x, y, _, _ := f.components[name].bounds()
+		ps[i] = [2]int{x, y}
+	}
+	return ps
+}
+
+func TestLayoutModes(t *testing.T) {
+	tests := []struct {
+		name string
+		mode LayoutMode
+		want [3][2]int
+	}{
+		// one column after the widest description, "Email address"
+		{"grid", LayoutGrid, [3][2]int{{17, 1}, {17, 3}, {17, 5}}},
+		// descriptions on the row above each component
+		{"stack", LayoutStack, [3][2]int{{2, 2}, {2, 5}, {2, 8}}},
+		// b doesn't fit after a within 38 columns so it wraps
+		// while c just fits after b
+		{"rows", LayoutRows, [3][2]int{{8, 1}, {17, 3}, {37, 3}}},
+	}
+	for _, tt := range tests {
+		f := layoutForm(t, 40, 24)
+		f.SetLayout(Layout{Mode: tt.mode, X: 2, Y: 1, Spacing: 1})
+		if got := positions(f); got != tt.want {
+			t.Errorf("%s: positions = %v, want %v", tt.name, got, tt.want)
+		}
+		_, _, above := f.components["a"].labelSize()
+		if want := tt.mode == LayoutStack; (above == 1) != want {
+			t.Errorf("%s: description above is %v, want %v", tt.name, above == 1, want)
+		}
+	}
+}
+
+func TestLayoutNoSpacing(t *testing.T) {
+	f := layoutForm(t, 40, 24)
+	f.SetLayout(Layout{Mode: LayoutStack})
+	if got, want := positions(f), [3][2]int{{0, 1}, {0, 3}, {0, 5}}; got != want {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+	// switching modes moves descriptions back beside components
+	f.SetLayout(Layout{Mode: LayoutGrid})
+	if got, want := positions(f), [3][2]int{{15, 0}, {15, 1}, {15, 2}}; got != want {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+}
+
+func TestLayoutAnchors(t *testing.T) {
+	// the grid form is 30 by 5 and the screen 40 by 24 so it's
+	// centered 5 cells in and 9 rows down before the offsets
+	tests := []struct {
+		anchor Anchor
+		want   [2]int
+	}{
+		{AnchorTopLeft, [2]int{17, 1}},
+		{AnchorTop, [2]int{22, 1}},
+		{AnchorTopRight, [2]int{23, 1}},
+		{AnchorLeft, [2]int{17, 10}},
+		{AnchorCenter, [2]int{22, 10}},
+		{AnchorRight, [2]int{23, 10}},
+		{AnchorBottomLeft, [2]int{17, 18}},
+		{AnchorBottom, [2]int{22, 18}},
+		{AnchorBottomRight, [2]int{23, 18}},
+	}
+	for _, tt := range tests {
+		f := layoutForm(t, 40, 24)
+		f.SetLayout(Layout{X: 2, Y: 1, Anchor: tt.anchor, Spacing: 1})
+		if got := positions(f)[0]; got != tt.want {
+			t.Errorf("anchor %d: a at %v, want %v", tt.anchor, got, tt.want)
+		}
+	}
+
+	f := layoutForm(t, 40, 24)
+	f.SetLayout(Layout{X: 2, Y: 1, Anchor: AnchorBottomRight, Spacing: 1, Center: true})
+	if got, want := positions(f)[0], [2]int{20, 9}; got != want {
+		t.Errorf("centered: a at %v, want %v", got, want)
+	}
+	// forms pushed off the top left are moved back on
+	f.SetLayout(Layout{X: 20, Y: 30, Anchor: AnchorBottomRight})
+	if got, want := positions(f)[0], [2]int{15, 0}; got != want {
+		t.Errorf("offscreen: a at %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,373 @@
+package ugform
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FormSpec is a declarative description of a form which can be
+// loaded from a JSON or YAML document with LoadForm
+type FormSpec struct {
+	// Name of the form
+	Name string `yaml:"name"`
+
+	// SubmitKey is the tcell name of the key that submits
+	// the form such as "Enter" or "Ctrl-S". It replaces the
+	// form's other ActionSubmit bindings. Defaults to Enter.
+	SubmitKey string `yaml:"submitKey"`
+
+	// Readline switches the form to ReadlineKeymap before the
+	// SubmitKey is applied
+	Readline bool `yaml:"readline"`
+
+	// Summary is the optional area for form level errors
+	Summary *SummarySpec `yaml:"summary"`
+
+	// Layout optionally positions the fields automatically in
+	// which case their x and y are ignored
+	Layout *LayoutSpec `yaml:"layout"`
+
+	// Fields of the form in any order. Tab order is taken
+	// from each field's TabOrder.
+	Fields []FieldSpec `yaml:"fields"`
+}
+
+// SummarySpec describes the form's error summary area. See
+// Form.SetSummaryArea for details.
+type SummarySpec struct {
+	X     int       `yaml:"x"`
+	Y     int       `yaml:"y"`
+	Width int       `yaml:"width"`
+	Rows  int       `yaml:"rows"`
+	Style StyleSpec `yaml:"style"`
+}
+
+// LayoutSpec describes the form's Layout. Mode is one of
+// "grid" (the default), "stack", or "rows" and Anchor is one of
+// "topLeft" (the default), "top", "topRight", "left", "center",
+// "right", "bottomLeft", "bottom", or "bottomRight".
+type LayoutSpec struct {
+	Mode    string `yaml:"mode"`
+	X       int    `yaml:"x"`
+	Y       int    `yaml:"y"`
+	Anchor  string `yaml:"anchor"`
+	Spacing int    `yaml:"spacing"`
+	Center  bool   `yaml:"center"`
+}
+
+// StyleSpec describes a tcell Style using color names which
+// are converted with StyleHelper
+type StyleSpec struct {
+	Fg    string `yaml:"fg"`
+	Bg    string `yaml:"bg"`
+	Blink bool   `yaml:"blink"`
+	Bold  bool   `yaml:"bold"`
+}
+
+// style converts the StyleSpec into a tcell Style
+func (st StyleSpec) style() tcell.Style {
+	return StyleHelper(st.Fg, st.Bg).Blink(st.Blink).Bold(st.Bold)
+}
+
+// StylesSpec holds every style a field might use. Each field
+// type only looks at the styles that its Add input uses.
+type StylesSpec struct {
+	Cursor      StyleSpec `yaml:"cursor"`
+	Fill        StyleSpec `yaml:"fill"`
+	Text        StyleSpec `yaml:"text"`
+	Selection   StyleSpec `yaml:"selection"`
+	Description StyleSpec `yaml:"description"`
+	Error       StyleSpec `yaml:"error"`
+	Focus       StyleSpec `yaml:"focus"`
+	Box         StyleSpec `yaml:"box"`
+	Option      StyleSpec `yaml:"option"`
+	List        StyleSpec `yaml:"list"`
+	Highlight   StyleSpec `yaml:"highlight"`
+	Button      StyleSpec `yaml:"button"`
+}
+
+// FieldSpec describes a single component of a form. Type is
+// one of "text" (the default), "checkbox", "radio", "select",
+// or "button" and the remaining properties mirror the matching
+// Add input struct.
+type FieldSpec struct {
+	Type            string     `yaml:"type"`
+	Name            string     `yaml:"name"`
+	Description     string     `yaml:"description"`
+	Default         string     `yaml:"default"`
+	TabOrder        int        `yaml:"tabOrder"`
+	X               int        `yaml:"x"`
+	Y               int        `yaml:"y"`
+	Width           int        `yaml:"width"`
+	Height          int        `yaml:"height"`
+	Multiline       bool       `yaml:"multiline"`
+	ViMode          bool       `yaml:"viMode"`
+	Direction       string     `yaml:"direction"`
+	Password        bool       `yaml:"password"`
+	ShowDescription bool       `yaml:"showDescription"`
+	HasFocus        bool       `yaml:"hasFocus"`
+	Required        bool       `yaml:"required"`
+	MinLength       int        `yaml:"minLength"`
+	MaxLength       int        `yaml:"maxLength"`
+	Pattern         string     `yaml:"pattern"`
+	Options         []Option   `yaml:"options"`
+	Horizontal      bool       `yaml:"horizontal"`
+	ListHeight      int        `yaml:"listHeight"`
+	Label           string     `yaml:"label"`
+	Action          string     `yaml:"action"`
+	Styles          StylesSpec `yaml:"styles"`
+}
+
+// LoadForm builds a new form on screen s from the JSON or YAML
+// document read from r. Since JSON is valid YAML either format
+// is accepted. Unknown properties are rejected to catch typos.
+func LoadForm(s tcell.Screen, r io.Reader) (f *Form, err error) {
+	spec := FormSpec{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err = dec.Decode(&spec); err != nil {
+		return f, err
+	}
+	f = NewForm(s)
+	err = spec.AddTo(f)
+	return f, err
+}
+
+// AddTo adds the spec's fields to an existing form and applies
+// its form level settings
+func (spec *FormSpec) AddTo(f *Form) (err error) {
+	if spec.Name != "" {
+		f.Name = spec.Name
+	}
+	if spec.Readline {
+		f.Keymap = ReadlineKeymap()
+	}
+	if spec.SubmitKey != "" {
+		key, err := keyByName(spec.SubmitKey)
+		if err != nil {
+			return err
+		}
+		f.Keymap.UnbindAction(ActionSubmit)
+		f.Keymap.BindKey(key, tcell.ModNone, ActionSubmit)
+	}
+	if sum := spec.Summary; sum != nil {
+		f.SetSummaryArea(sum.X, sum.Y, sum.Width, sum.Rows, sum.Style.style())
+	}
+	for i := range spec.Fields {
+		if err = spec.Fields[i].addTo(f); err != nil {
+			return fmt.Errorf("field %q: %w", spec.Fields[i].Name, err)
+		}
+	}
+	if lay := spec.Layout; lay != nil {
+		mode, err := layoutModeByName(lay.Mode)
+		if err != nil {
+			return err
+		}
+		anchor, err := anchorByName(lay.Anchor)
+		if err != nil {
+			return err
+		}
+		f.SetLayout(Layout{
+			Mode:    mode,
+			X:       lay.X,
+			Y:       lay.Y,
+			Anchor:  anchor,
+			Spacing: lay.Spacing,
+			Center:  lay.Center,
+		})
+	}
+	return err
+}
+
+// addTo adds the single field described by fs to the form
+func (fs *FieldSpec) addTo(f *Form) (err error) {
+	st := fs.Styles
+	switch strings.ToLower(fs.Type) {
+	case "", "text":
+		dir, err := directionByName(fs.Direction)
+		if err != nil {
+			return err
+		}
+		return f.AddTextBox(&AddTextBoxInput{
+			Name:             fs.Name,
+			Description:      fs.Description,
+			DefaultValue:     fs.Default,
+			TabOrder:         fs.TabOrder,
+			PositionX:        fs.X,
+			PositionY:        fs.Y,
+			Width:            fs.Width,
+			Height:           fs.Height,
+			Multiline:        fs.Multiline,
+			ViMode:           fs.ViMode,
+			Direction:        dir,
+			StyleCursor:      st.Cursor.style(),
+			StyleFill:        st.Fill.style(),
+			StyleText:        st.Text.style(),
+			StyleSelection:   st.Selection.style(),
+			StyleDescription: st.Description.style(),
+			ShowDescription:  fs.ShowDescription,
+			HasFocus:         fs.HasFocus,
+			Password:         fs.Password,
+			Required:         fs.Required,
+			MinLength:        fs.MinLength,
+			MaxLength:        fs.MaxLength,
+			Pattern:          fs.Pattern,
+			StyleError:       st.Error.style(),
+		})
+	case "checkbox":
+		checked := false
+		if fs.Default != "" {
+			checked, err = strconv.ParseBool(fs.Default)
+			if err != nil {
+				return err
+			}
+		}
+		return f.AddCheckBox(&AddCheckBoxInput{
+			Name:             fs.Name,
+			Description:      fs.Description,
+			DefaultValue:     checked,
+			TabOrder:         fs.TabOrder,
+			PositionX:        fs.X,
+			PositionY:        fs.Y,
+			StyleFocus:       st.Focus.style(),
+			StyleBox:         st.Box.style(),
+			StyleDescription: st.Description.style(),
+			ShowDescription:  fs.ShowDescription,
+			StyleError:       st.Error.style(),
+			HasFocus:         fs.HasFocus,
+		})
+	case "radio":
+		return f.AddRadioGroup(&AddRadioGroupInput{
+			Name:             fs.Name,
+			Description:      fs.Description,
+			Options:          fs.Options,
+			DefaultValue:     fs.Default,
+			TabOrder:         fs.TabOrder,
+			PositionX:        fs.X,
+			PositionY:        fs.Y,
+			Horizontal:       fs.Horizontal,
+			StyleFocus:       st.Focus.style(),
+			StyleOption:      st.Option.style(),
+			StyleDescription: st.Description.style(),
+			ShowDescription:  fs.ShowDescription,
+			StyleError:       st.Error.style(),
+			HasFocus:         fs.HasFocus,
+		})
+	case "select":
+		return f.AddSelect(&AddSelectInput{
+			Name:             fs.Name,
+			Description:      fs.Description,
+			Options:          fs.Options,
+			DefaultValue:     fs.Default,
+			TabOrder:         fs.TabOrder,
+			PositionX:        fs.X,
+			PositionY:        fs.Y,
+			Width:            fs.Width,
+			ListHeight:       fs.ListHeight,
+			StyleCursor:      st.Cursor.style(),
+			StyleText:        st.Text.style(),
+			StyleList:        st.List.style(),
+			StyleHighlight:   st.Highlight.style(),
+			StyleDescription: st.Description.style(),
+			ShowDescription:  fs.ShowDescription,
+			StyleError:       st.Error.style(),
+			HasFocus:         fs.HasFocus,
+		})
+	case "button":
+		action, err := buttonActionByName(fs.Action)
+		if err != nil {
+			return err
+		}
+		return f.AddButton(&AddButtonInput{
+			Name:        fs.Name,
+			Label:       fs.Label,
+			Action:      action,
+			TabOrder:    fs.TabOrder,
+			PositionX:   fs.X,
+			PositionY:   fs.Y,
+			StyleFocus:  st.Focus.style(),
+			StyleButton: st.Button.style(),
+			HasFocus:    fs.HasFocus,
+		})
+	}
+	return fmt.Errorf("unknown field type %q", fs.Type)
+}
+
+// buttonActionByName converts "submit", "cancel", or "custom"
+// into a ButtonAction. Empty defaults to custom.
+func buttonActionByName(name string) (ButtonAction, error) {
+	switch strings.ToLower(name) {
+	case "", "custom":
+		return ButtonCustom, nil
+	case "submit":
+		return ButtonSubmit, nil
+	case "cancel":
+		return ButtonCancel, nil
+	}
+	return ButtonCustom, fmt.Errorf("unknown button action %q", name)
+}
+
+// layoutModeByName converts "grid", "stack", or "rows" into
+// a LayoutMode. Empty defaults to grid.
+func layoutModeByName(name string) (LayoutMode, error) {
+	switch strings.ToLower(name) {
+	case "", "grid":
+		return LayoutGrid, nil
+	case "stack":
+		return LayoutStack, nil
+	case "rows":
+		return LayoutRows, nil
+	}
+	return LayoutGrid, fmt.Errorf("unknown layout mode %q", name)
+}
+
+// anchorNames lists the names of every Anchor in order
+var anchorNames = []string{
+	"topLeft", "top", "topRight",
+	"left", "center", "right",
+	"bottomLeft", "bottom", "bottomRight",
+}
+
+// anchorByName converts a name from anchorNames into an
+// Anchor ignoring case. Empty defaults to the top left.
+func anchorByName(name string) (Anchor, error) {
+	if name == "" {
+		return AnchorTopLeft, nil
+	}
+	for i, n := range anchorNames {
+		if strings.EqualFold(n, name) {
+			return Anchor(i), nil
+		}
+	}
+	return AnchorTopLeft, fmt.Errorf("unknown anchor %q", name)
+}
+
+// directionByName converts "ltr", "rtl", or "auto" into a
+// Direction. Empty defaults to left to right.
+func directionByName(name string) (Direction, error) {
+	switch strings.ToLower(name) {
+	case "", "ltr":
+		return DirectionLTR, nil
+	case "rtl":
+		return DirectionRTL, nil
+	case "auto":
+		return DirectionAuto, nil
+	}
+	return DirectionLTR, fmt.Errorf("unknown direction %q", name)
+}
+
+// keyByName looks up a tcell Key by its name as listed in
+// tcell.KeyNames ignoring case, e.g. "Enter" or "Ctrl-S"
+func keyByName(name string) (tcell.Key, error) {
+	for k, n := range tcell.KeyNames {
+		if strings.EqualFold(n, name) {
+			return k, nil
+		}
+	}
+	return tcell.KeyNUL, fmt.Errorf("unknown key %q", name)
+}
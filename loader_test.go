@@ -0,0 +1,158 @@
+package ugform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLoadForm(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+		values  map[string]string
+	}{
+		{
+			name: "json",
+			doc: `{"name": "login", "fields": [
+				{"name": "user", "x": 10, "y": 1, "width": 10, "default": "bob"},
+				{"type": "checkbox", "name": "remember", "tabOrder": 1, "y": 3, "default": "true"}
+			]}`,
+			values: map[string]string{"user": "bob", "remember": "true"},
+		},
+		{
+			name: "yaml",
+			doc: `
+name: login
+fields:
+  - name: user
+    x: 10
+    y: 1
+    width: 10
+    default: bob
+  - type: Checkbox
+    name: remember
+    tabOrder: 1
+    y: 3
+`,
+			values: map[string]string{"user": "bob", "remember": "false"},
+		},
+		{
+			name:   "checkbox default as a number",
+			doc:    `{"fields": [{"type": "checkbox", "name": "c", "default": "1"}]}`,
+			values: map[string]string{"c": "true"},
+		},
+		{
+			name:    "checkbox default that isn't a bool",
+			doc:     `{"fields": [{"type": "checkbox", "name": "c", "default": "yes"}]}`,
+			wantErr: `field "c"`,
+		},
+		{
+			name:    "misspelled field property",
+			doc:     "fields:\n  - name: a\n    widht: 5\n",
+			wantErr: "widht",
+		},
+		{
+			name:    "misspelled form property",
+			doc:     `{"nmae": "login", "fields": [{"name": "a"}]}`,
+			wantErr: "nmae",
+		},
+		{
+			name:    "unknown field type",
+			doc:     `{"fields": [{"type": "slider", "name": "a"}]}`,
+			wantErr: `unknown field type "slider"`,
+		},
+		{
+			name:    "unknown button action",
+			doc:     `{"fields": [{"type": "button", "name": "b", "action": "launch"}]}`,
+			wantErr: `unknown button action "launch"`,
+		},
+		{
+			name:    "unknown direction",
+			doc:     `{"fields": [{"name": "a", "direction": "sideways"}]}`,
+			wantErr: `unknown direction "sideways"`,
+		},
+		{
+			name:    "unknown layout mode",
+			doc:     `{"layout": {"mode": "spiral"}, "fields": [{"name": "a"}]}`,
+			wantErr: `unknown layout mode "spiral"`,
+		},
+		{
+			name:    "unknown anchor",
+			doc:     `{"layout": {"anchor": "middle"}, "fields": [{"name": "a"}]}`,
+			wantErr: `unknown anchor "middle"`,
+		},
+		{
+			name:    "unknown submit key",
+			doc:     `{"submitKey": "Ctrl-Banana", "fields": [{"name": "a"}]}`,
+			wantErr: `unknown key "Ctrl-Banana"`,
+		},
+	}
+	for _, tt := range tests {
+		f, err := LoadForm(newTestScreen(t, 80, 24), strings.NewReader(tt.doc))
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: err = %v, want one containing %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		got := f.Collect()
+		for k, want := range tt.values {
+			if got[k] != want {
+				t.Errorf("%s: %s = %q, want %q", tt.name, k, got[k], want)
+			}
+		}
+		if len(got) != len(tt.values) {
+			t.Errorf("%s: got fields %v, want %v", tt.name, got, tt.values)
+		}
+	}
+}
+
+func TestLoadFormSettings(t *testing.T) {
+	doc := `
+name: settings
+submitKey: ctrl-s
+summary: {x: 0, y: 20, width: 40, rows: 2}
+layout: {mode: stack, x: 2, y: 1, anchor: TOPLEFT}
+fields:
+  - name: host
+    description: Host
+    showDescription: true
+    width: 10
+  - name: mode
+    type: radio
+    tabOrder: 1
+    default: b
+    horizontal: true
+    options: [{label: A, value: a}, {label: B, value: b}]
+`
+	f, err := LoadForm(newTestScreen(t, 80, 24), strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name != "settings" {
+		t.Errorf("Name = %q", f.Name)
+	}
+	if got := lookup(f.Keymap, tcell.KeyCtrlS, 0, tcell.ModNone); got != ActionSubmit {
+		t.Errorf("Ctrl-S = %v, want ActionSubmit", got)
+	}
+	if got := lookup(f.Keymap, tcell.KeyEnter, 0, tcell.ModNone); got != ActionNone {
+		t.Errorf("Enter = %v, want ActionNone once the submit key is replaced", got)
+	}
+	if f.summary.y != 20 || f.summary.h != 2 {
+		t.Errorf("summary = %+v", f.summary)
+	}
+	// stacked with the description of host on the row above
+	if x, y, _, _ := f.components["host"].bounds(); x != 2 || y != 2 {
+		t.Errorf("host at %d,%d, want 2,2", x, y)
+	}
+	if got := f.Collect()["mode"]; got != "b" {
+		t.Errorf("mode = %q, want b", got)
+	}
+}
@@ -0,0 +1,76 @@
+package ugform
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// inside reports whether x, y falls within the rectangle
+// starting at bx, by that is bw wide and bh high
+func inside(x, y, bx, by, bw, bh int) bool {
+	return x >= bx && x < bx+bw && y >= by && y < by+bh
+}
+
+// componentAt returns the component drawn at x, y giving the
+// focused component first pick so open popups win over
+// whatever is underneath them. It returns nil if there isn't
+// any component there.
+func (f *Form) componentAt(x, y int) component {
+	if f.focus != nil && f.focus.contains(x, y) {
+		return f.focus
+	}
+	for _, c := range f.ordered() {
+		if c.contains(x, y) {
+			return c
+		}
+	}
+	return nil
+}
+
+// handleMouse focuses and clicks on whatever component is
+// under the primary button when it is pressed and scrolls
+// either the component under the wheel or the whole form.
+// Mouse events are only delivered once EnableMouse has been
+// called on the screen.
+func (f *Form) handleMouse(ev *tcell.EventMouse) {
+	x, sy := ev.Position()
+	// components work in form rather than screen coordinates
+	y := sy + f.view.top
+	buttons := ev.Buttons()
+	// tcell repeats button state while dragging so only
+	// react when the primary button first goes down
+	pressed := buttons&tcell.Button1 != 0 && f.buttons&tcell.Button1 == 0
+	f.buttons = buttons
+	switch {
+	case pressed && f.clickBar(x, sy):
+	case pressed:
+		c := f.componentAt(x, y)
+		if c == nil {
+			if l, ok := f.focus.(*selectList); ok && l.open {
+				l.closePopup()
+			}
+			return
+		}
+		if c != f.focus {
+			if v, ok := f.focus.(validatable); ok {
+				v.validate()
+			}
+			f.setFocus(c)
+		}
+		old := c.value()
+		c.click(x, y)
+		f.changed(c, old)
+	case buttons&tcell.WheelUp != 0:
+		f.wheel(x, y, -1)
+	case buttons&tcell.WheelDown != 0:
+		f.wheel(x, y, 1)
+	}
+}
+
+// wheel scrolls the component at x, y by n rows or the whole
+// form if the component can't scroll itself
+func (f *Form) wheel(x, y, n int) {
+	if c := f.componentAt(x, y); c != nil && c.wheel(n) {
+		return
+	}
+	f.scroll(n)
+}
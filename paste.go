@@ -0,0 +1,67 @@
+package ugform
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// pasteKey adds the rune a key event stands for to the paste
+// that is in progress. Terminals send pasted newlines and tabs
+// as Enter and Tab keys so those are turned back into runes
+// rather than submitting or moving focus. Enter is a carriage
+// return which cleanPaste turns into a newline.
+func (f *Form) pasteKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		f.pasted = append(f.pasted, ev.Rune())
+	case tcell.KeyEnter:
+		f.pasted = append(f.pasted, '\r')
+	case tcell.KeyLF:
+		f.pasted = append(f.pasted, '\n')
+	case tcell.KeyTab:
+		f.pasted = append(f.pasted, '\t')
+	}
+}
+
+// handlePaste starts collecting keys when a bracketed paste
+// begins and hands the whole paste to the focused component
+// when it ends so it is inserted and drawn in one step. Paste
+// events are only delivered once EnablePaste has been called
+// on the screen.
+func (f *Form) handlePaste(ev *tcell.EventPaste) {
+	if ev.Start() {
+		f.pasting = true
+		f.pasted = f.pasted[:0]
+		return
+	}
+	f.pasting = false
+	if len(f.pasted) == 0 {
+		return
+	}
+	c, old := f.focus, f.focus.value()
+	c.paste(f.pasted)
+	f.changed(c, old)
+}
+
+// cleanPaste prepares pasted text for a field. Line endings
+// of CRLF or a lone CR become a single newline, tabs become
+// spaces, and newlines are kept only if keepNewlines is set.
+func cleanPaste(text []rune, keepNewlines bool) (clean []rune) {
+	for i, r := range text {
+		if r == '\r' {
+			if i+1 < len(text) && text[i+1] == '\n' {
+				continue
+			}
+			r = '\n'
+		}
+		switch r {
+		case '\t':
+			r = ' '
+		case '\n':
+			if !keepNewlines {
+				continue
+			}
+		}
+		clean = append(clean, r)
+	}
+	return clean
+}
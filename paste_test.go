@@ -0,0 +1,50 @@
+package ugform
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCleanPaste(t *testing.T) {
+	tests := []struct {
+		in           string
+		keepNewlines bool
+		want         string
+	}{
+		{"plain", false, "plain"},
+		{"a\tb", false, "a b"},
+		{"one\r\ntwo\n", false, "onetwo"},
+		{"one\r\ntwo\n", true, "one\ntwo\n"},
+		{"\r\r", true, "\n\n"},
+		{"a\rb\r\nc", true, "a\nb\nc"},
+		{"a\rb", false, "ab"},
+		{"", false, ""},
+		{"日本\té", false, "日本 é"},
+	}
+	for _, tt := range tests {
+		if got := string(cleanPaste([]rune(tt.in), tt.keepNewlines)); got != tt.want {
+			t.Errorf("cleanPaste(%q, %v) = %q, want %q", tt.in, tt.keepNewlines, got, tt.want)
+		}
+	}
+}
+
+func TestPasteKeysCRLF(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "m", Width: 10, Height: 3, Multiline: true})
+	f.Start()
+	f.handlePaste(tcell.NewEventPaste(true))
+	for _, ev := range []*tcell.EventKey{
+		tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyLF, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+	} {
+		f.pasteKey(ev)
+	}
+	f.handlePaste(tcell.NewEventPaste(false))
+	if got := tb.value(); got != "a\nb\nc" {
+		t.Errorf("pasted %q, want %q", got, "a\nb\nc")
+	}
+}
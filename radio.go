@@ -0,0 +1,340 @@
+package ugform
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Option is a single choice offered by a radio group or
+// select list
+type Option struct {
+	// Label is what is displayed to the user
+	Label string `yaml:"label"`
+
+	// Value is what is returned when collecting results. If
+	// left empty then the Label is used instead.
+	Value string `yaml:"value"`
+}
+
+// value returns the Value of the option falling back
+// to the Label if no Value was provided
+func (o Option) value() string {
+	if o.Value == "" {
+		return o.Label
+	}
+	return o.Value
+}
+
+type radioGroup struct {
+	tabOrder          int
+	name, description string
+	options           []Option
+	sel, def          int          // index of the selected and default option
+	px, py            int          // radioGroup position
+	cs, os, ds        tcell.Style  // focused, option, and description style
+	s                 tcell.Screen // need direct access to screen
+	horizontal        bool
+	showDescription   bool
+	labelAbove        bool // draw the description above rather than beside
+	focused           bool
+	errorLine
+}
+
+func (r *radioGroup) getName() string {
+	return r.name
+}
+
+func (r *radioGroup) getTabOrder() int {
+	return r.tabOrder
+}
+
+func (r *radioGroup) getDescription() string {
+	return r.description
+}
+
+// value returns the value of the selected option
+func (r *radioGroup) value() string {
+	if len(r.options) == 0 {
+		return ""
+	}
+	return r.options[r.sel].value()
+}
+
+// setValue selects the option whose value is v
+func (r *radioGroup) setValue(v string) error {
+	i, err := optionIndex(r.options, v)
+	if err != nil {
+		return err
+	}
+	r.sel = i
+	return nil
+}
+
+func (r *radioGroup) defaultValue() string {
+	if len(r.options) == 0 {
+		return ""
+	}
+	return r.options[r.def].value()
+}
+
+// clear selects the first option since a radioGroup always
+// has exactly one option selected
+func (r *radioGroup) clear() {
+	r.sel = 0
+}
+
+// redraw draws the radioGroup
+func (r *radioGroup) redraw() {
+	r.draw()
+}
+
+// optionIndex returns the index of the option whose value is v
+func optionIndex(options []Option, v string) (int, error) {
+	for i, o := range options {
+		if o.value() == v {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no option with value %q", v)
+}
+
+// shift moves the radioGroup by x and y
+func (r *radioGroup) shift(x, y int) {
+	r.px += x
+	r.py += y
+}
+
+// optionXY returns the screen position of the option at index i.
+// Options are stacked vertically unless the group is horizontal
+// in which case they are laid out left to right with two cells
+// of padding between them.
+func (r *radioGroup) optionXY(i int) (x, y int) {
+	if !r.horizontal {
+		return r.px, r.py + i
+	}
+	x = r.px
+	for _, o := range r.options[:i] {
+		x += textWidth(o.Label) + 6
+	}
+	return x, r.py
+}
+
+// draw draws every option in the group marking the selected
+// option and highlighting it if the group has focus
+func (r *radioGroup) draw() {
+	for i, o := range r.options {
+		style := r.os
+		mark := csr("")
+		if i == r.sel {
+			mark = csr("*")
+			if r.focused {
+				style = r.cs
+			}
+		}
+		x, y := r.optionXY(i)
+		r.s.SetContent(x, y, csr("("), nil, style)
+		r.s.SetContent(x+1, y, mark, nil, style)
+		r.s.SetContent(x+2, y, csr(")"), nil, style)
+		r.s.SetContent(x+3, y, csr(""), nil, style)
+		drawString(r.s, x+4, y, o.Label, -1, style)
+	}
+	r.s.Show()
+}
+
+// start draws the radioGroup and its description
+func (r *radioGroup) start() {
+	if r.showDescription {
+		drawDescription(r.s, r.px, r.py, r.description, r.labelAbove, r.ds)
+	}
+	r.draw()
+}
+
+// showCursor highlights the selected option
+func (r *radioGroup) showCursor() {
+	r.focused = true
+	r.draw()
+}
+
+// hideCursor removes the highlight from the selected option
+func (r *radioGroup) hideCursor() {
+	r.focused = false
+	r.draw()
+}
+
+// choose selects the option at index i wrapping around
+// at either end of the list of options
+func (r *radioGroup) choose(i int) {
+	if len(r.options) == 0 {
+		return
+	}
+	r.sel = (i + len(r.options)) % len(r.options)
+	r.draw()
+}
+
+// handleKey moves the selection with the arrow keys or Space
+// and reports whether or not the key was consumed
+func (r *radioGroup) handleKey(ev *tcell.EventKey, a Action) bool {
+	switch a {
+	case ActionUp, ActionLeft:
+		r.choose(r.sel - 1)
+	case ActionDown, ActionRight:
+		r.choose(r.sel + 1)
+	case ActionNone:
+		if !typed(ev) || ev.Rune() != ' ' {
+			return false
+		}
+		r.choose(r.sel + 1)
+	default:
+		return false
+	}
+	return true
+}
+
+// optionWidth returns the number of cells taken up by the
+// option at index i including its (*) marker
+func (r *radioGroup) optionWidth(i int) int {
+	return textWidth(r.options[i].Label) + 4
+}
+
+// bounds returns the area of the screen covered by all of
+// the group's options
+func (r *radioGroup) bounds() (x, y, w, h int) {
+	for i := range r.options {
+		ox, oy := r.optionXY(i)
+		if right := ox + r.optionWidth(i) - r.px; right > w {
+			w = right
+		}
+		h = oy - r.py + 1
+	}
+	return r.px, r.py, w, h
+}
+
+// optionAt returns the index of the option drawn at x, y or
+// -1 if there isn't one there
+func (r *radioGroup) optionAt(x, y int) int {
+	for i := range r.options {
+		ox, oy := r.optionXY(i)
+		if inside(x, y, ox, oy, r.optionWidth(i), 1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// contains reports whether x, y is on one of the options
+func (r *radioGroup) contains(x, y int) bool {
+	return r.optionAt(x, y) >= 0
+}
+
+// click selects the option at x, y
+func (r *radioGroup) click(x, y int) {
+	if i := r.optionAt(x, y); i >= 0 {
+		r.choose(i)
+	}
+}
+
+func (r *radioGroup) wheel(n int) bool {
+	return false
+}
+
+// labelSize returns the space taken up by the radioGroup's
+// description
+func (r *radioGroup) labelSize() (left, right, above int) {
+	left, above = descriptionSize(r.description, r.showDescription, r.labelAbove)
+	return left, 0, above
+}
+
+func (r *radioGroup) setLabelAbove(above bool) {
+	r.labelAbove = above
+}
+
+func (r *radioGroup) paste(text []rune) {}
+
+// AddRadioGroup is a constructor for adding a new radio group to
+// the form. The whole group is a single stop in the form's tab
+// order and exactly one of its options is always selected.
+func (f *Form) AddRadioGroup(in *AddRadioGroupInput) (err error) {
+	r := radioGroup{}
+	r.name = in.Name
+	r.description = in.Description
+	r.options = in.Options
+	for i, o := range r.options {
+		if o.value() == in.DefaultValue {
+			r.def = i
+			break
+		}
+	}
+	r.sel = r.def
+	r.tabOrder = in.TabOrder
+	f.tabOrder[r.tabOrder] = r.name
+	r.s = f.s
+	r.px = in.PositionX
+	r.py = in.PositionY
+	r.cs = in.StyleFocus
+	r.os = in.StyleOption
+	r.ds = in.StyleDescription
+	r.horizontal = in.Horizontal
+	r.showDescription = in.ShowDescription
+	r.es = in.StyleError
+	f.components[r.name] = &r
+	if in.HasFocus {
+		f.focus = &r
+	}
+	return err
+}
+
+// AddRadioGroupInput provides all the input parameters for the
+// AddRadioGroup constructor
+type AddRadioGroupInput struct {
+	// Name of the radio group which will be included
+	// when collecting results
+	Name string
+
+	// Description of the radio group which will be displayed
+	// to the left of the first option
+	Description string
+
+	// Options to choose from in the order they are displayed
+	Options []Option
+
+	// The DefaultValue is the Value of the option that is
+	// selected initially. If no option matches then the
+	// first option is selected.
+	DefaultValue string
+
+	// TabOrder of the radio group for this form. Must be unique
+	// within a form or unstable tab behavior could result
+	TabOrder int
+
+	// PositionX is the x-axis position of the first option
+	PositionX int
+
+	// PositionY is the y-axis position of the first option
+	PositionY int
+
+	// Horizontal lays the options out on a single row instead
+	// of stacking them one per row
+	Horizontal bool
+
+	// tcell Style to use for the selected option when the
+	// radio group has focus
+	StyleFocus tcell.Style
+
+	// tcell Style to use for the options
+	StyleOption tcell.Style
+
+	// tcell Style for the radio group's description.
+	StyleDescription tcell.Style
+
+	// Whether or not to show the description to the user.
+	ShowDescription bool
+
+	// tcell Style for error messages attached by form level
+	// validators which are drawn underneath.
+	StyleError tcell.Style
+
+	// Whether or not this radio group has focus when the form's
+	// polling method is activated.
+	HasFocus bool
+}
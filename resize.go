@@ -0,0 +1,63 @@
+package ugform
+
+// fitter is implemented by components which can get narrower
+// so that they still fit on a smaller screen
+type fitter interface {
+	component
+	fit(width int)
+}
+
+// resize lays the form out again after the screen changes size
+// and redraws it keeping the focus and each component's state.
+// Components that would run off the right edge of the screen
+// are narrowed to fit and widened again once there's room.
+func (f *Form) resize() {
+	if !f.started {
+		return
+	}
+	// close any popup before the screen underneath it changes
+	f.focus.hideCursor()
+	width, _ := f.s.Size()
+	f.fit(-1)
+	f.applyLayout()
+	f.fit(width)
+	f.repaint()
+	f.reveal(f.focus)
+	f.s.Sync()
+}
+
+// fit narrows every component that can be narrowed to fit
+// within width columns or restores their full width when width
+// is negative
+func (f *Form) fit(width int) {
+	for _, c := range f.components {
+		if fc, ok := c.(fitter); ok {
+			fc.fit(width)
+		}
+	}
+}
+
+// fitWidth returns full or less if needed so that something
+// starting at x with a last cell full cells further along ends
+// before column width. Negative widths don't limit it.
+func fitWidth(x, full, width int) int {
+	if width < 0 || x+full < width {
+		return full
+	}
+	if width-x-1 < 0 {
+		return 0
+	}
+	return width - x - 1
+}
+
+// fit narrows the textBox to end before column width. The
+// contents scroll within the narrower box.
+func (t *textBox) fit(width int) {
+	t.pw = fitWidth(t.px, t.fullWidth, width)
+}
+
+// fit narrows the selectList and its popup to end before
+// column width
+func (l *selectList) fit(width int) {
+	l.pw = fitWidth(l.px, l.fullWidth, width)
+}
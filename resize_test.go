@@ -0,0 +1,77 @@
+package ugform
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFitWidth(t *testing.T) {
+	tests := []struct {
+		x, full, width int
+		want           int
+	}{
+		{0, 10, 80, 10},
+		{5, 10, -1, 10},
+		// the last cell must land before column width
+		{5, 10, 16, 10},
+		{5, 10, 15, 9},
+		{5, 10, 10, 4},
+		{5, 10, 6, 0},
+		{5, 10, 3, 0},
+	}
+	for _, tt := range tests {
+		if got := fitWidth(tt.x, tt.full, tt.width); got != tt.want {
+			t.Errorf("fitWidth(%d, %d, %d) = %d, want %d", tt.x, tt.full, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	s := newTestScreen(t, 80, 24)
+	f := NewForm(s)
+	err := f.AddTextBox(&AddTextBoxInput{Name: "t", TabOrder: 0, PositionX: 5, Width: 30})
+	if err == nil {
+		err = f.AddSelect(&AddSelectInput{
+			Name: "s", TabOrder: 1, PositionX: 5, PositionY: 2, Width: 30,
+			Options: []Option{{Label: "one", Value: "1"}},
+		})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Start()
+	widths := func() (ws [2]int) {
+		for i, name := range []string{"t", "s"} {
+			_, _, ws[i], _ = f.components[name].bounds()
+		}
+		return ws
+	}
+	full := widths()
+	s.SetSize(20, 24)
+	f.resize()
+	for i, w := range widths() {
+		// everything ends before the last column
+		if 5+w > 20 || w >= full[i] {
+			t.Errorf("component %d is %d wide on a 20 column screen", i, w)
+		}
+	}
+	s.SetSize(80, 24)
+	f.resize()
+	if got := widths(); got != full {
+		t.Errorf("widths after growing back = %v, want %v", got, full)
+	}
+}
+
+func TestResizeReanchors(t *testing.T) {
+	f := layoutForm(t, 40, 24)
+	s := f.view.Screen.(tcell.SimulationScreen)
+	f.SetLayout(Layout{Anchor: AnchorBottomRight, Spacing: 1})
+	f.Start()
+	s.SetSize(60, 30)
+	f.resize()
+	// the 30 by 5 grid form hugs the new bottom right corner
+	if got, want := positions(f)[0], [2]int{45, 25}; got != want {
+		t.Errorf("a at %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,147 @@
+package ugform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Field is a single value collected from a form
+type Field struct {
+	// Name of the component the value came from
+	Name string
+
+	// Description of the component the value came from
+	Description string
+
+	// Value as it would be returned by Collect
+	Value string
+}
+
+// Results holds the values collected from a form and provides
+// typed accessors for them. Get one from Form.Results.
+type Results struct {
+	fields []Field
+	values map[string]string
+}
+
+// Results collects the current values of all of the form's
+// components, buttons excepted, in tab order
+func (f *Form) Results() *Results {
+	r := Results{values: make(map[string]string)}
+	for _, c := range f.ordered() {
+		if _, ok := c.(*button); ok {
+			continue
+		}
+		fd := Field{
+			Name:        c.getName(),
+			Description: c.getDescription(),
+			Value:       c.value(),
+		}
+		r.fields = append(r.fields, fd)
+		r.values[fd.Name] = fd.Value
+	}
+	return &r
+}
+
+// Fields returns every collected value in the tab order of
+// the form so they can be presented in the order the user
+// saw them
+func (r *Results) Fields() []Field {
+	return r.fields
+}
+
+// Map returns the collected values keyed by name just like
+// Collect does
+func (r *Results) Map() map[string]string {
+	m := make(map[string]string, len(r.values))
+	for k, v := range r.values {
+		m[k] = v
+	}
+	return m
+}
+
+// String returns the value of the named field or an error if
+// the form has no such field
+func (r *Results) String(name string) (string, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return v, fmt.Errorf("no field named %q", name)
+	}
+	return v, nil
+}
+
+// parse converts the value of the named field to the type of
+// out using the same rules as FromStruct
+func (r *Results) parse(name string, out interface{}) error {
+	s, err := r.String(name)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(out).Elem()
+	v, err := parseValue(rv.Type(), s)
+	if err != nil {
+		return fmt.Errorf("field %q %s", name, err)
+	}
+	rv.Set(v)
+	return nil
+}
+
+// Int returns the value of the named field as an int
+func (r *Results) Int(name string) (n int, err error) {
+	err = r.parse(name, &n)
+	return n, err
+}
+
+// Bool returns the value of the named field as a bool. Any
+// value accepted by strconv.ParseBool is allowed.
+func (r *Results) Bool(name string) (b bool, err error) {
+	err = r.parse(name, &b)
+	return b, err
+}
+
+// Float returns the value of the named field as a float64
+func (r *Results) Float(name string) (n float64, err error) {
+	err = r.parse(name, &n)
+	return n, err
+}
+
+// Duration returns the value of the named field parsed with
+// time.ParseDuration
+func (r *Results) Duration(name string) (d time.Duration, err error) {
+	err = r.parse(name, &d)
+	return d, err
+}
+
+// Time returns the value of the named field parsed with
+// time.Parse using the given layout
+func (r *Results) Time(name, layout string) (t time.Time, err error) {
+	s, err := r.String(name)
+	if err != nil {
+		return t, err
+	}
+	t, err = time.Parse(layout, strings.TrimSpace(s))
+	if err != nil {
+		return t, fmt.Errorf("field %q must be a time like %s", name, layout)
+	}
+	return t, nil
+}
+
+// Strings returns the value of the named field split on commas
+// and newlines with surrounding space trimmed and empty entries
+// dropped
+func (r *Results) Strings(name string) (ss []string, err error) {
+	s, err := r.String(name)
+	if err != nil {
+		return ss, err
+	}
+	for _, part := range strings.FieldsFunc(s, func(c rune) bool {
+		return c == ',' || c == '\n'
+	}) {
+		if part = strings.TrimSpace(part); part != "" {
+			ss = append(ss, part)
+		}
+	}
+	return ss, nil
+}
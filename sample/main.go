@@ -5,6 +5,7 @@ import (
 	"log"
 	"fmt"
 	"context"
+	"strings"
 	"time"
 	"github.com/inconshreveable/log15"
 	"github.com/gdamore/tcell/v2"
@@ -95,6 +96,10 @@ func main() {
 		log.Fatalf("%+v", err)
 	}
 	s.SetStyle(tcell.StyleDefault)
+	// forms handle clicks and the scroll wheel once enabled
+	s.EnableMouse()
+	// and insert pasted text in one go with bracketed paste
+	s.EnablePaste()
 
 	// create a new form 
 	sampleForm = ugform.NewForm(s)
@@ -121,11 +126,127 @@ func main() {
 			StyleDescription: ugform.StyleHelper("orange", "gray"),
 			ShowDescription: true,
 			Password: true,
+			Required: true,
+			MinLength: 8,
+			StyleError: ugform.StyleHelper("red", "black"),
 		},
 	)
 	if err != nil {
 		log.Fatalf("%+v", err)
 	}
+	// checkboxes join the same tab order as textboxes
+	err = customForm.AddCheckBox(
+		&ugform.AddCheckBoxInput{
+			Name: "remember",
+			Description: "Remember me: ",
+			TabOrder: 1,
+			PositionX: 45,
+			PositionY: 22,
+			StyleFocus: ugform.StyleHelper("black", "white"),
+			StyleBox: ugform.StyleHelper("white", "green"),
+			StyleDescription: ugform.StyleHelper("orange", "gray"),
+			ShowDescription: true,
+		},
+	)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	// radio groups are a single tab stop, arrows move inside
+	err = customForm.AddRadioGroup(
+		&ugform.AddRadioGroupInput{
+			Name: "role",
+			Description: "Role: ",
+			TabOrder: 2,
+			PositionX: 45,
+			PositionY: 24,
+			Horizontal: true,
+			DefaultValue: "dev",
+			Options: []ugform.Option{
+				{Label: "Developer", Value: "dev"},
+				{Label: "Operator", Value: "ops"},
+				{Label: "Manager", Value: "mgr"},
+			},
+			StyleFocus: ugform.StyleHelper("black", "white"),
+			StyleOption: ugform.StyleHelper("white", "green"),
+			StyleDescription: ugform.StyleHelper("orange", "gray"),
+			ShowDescription: true,
+		},
+	)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	// select lists open a filterable popup on Enter or Down
+	err = customForm.AddSelect(
+		&ugform.AddSelectInput{
+			Name: "region",
+			Description: "Region: ",
+			TabOrder: 3,
+			PositionX: 45,
+			PositionY: 26,
+			Width: 15,
+			ListHeight: 3,
+			DefaultValue: "us-east-1",
+			Options: []ugform.Option{
+				{Label: "us-east-1"},
+				{Label: "us-west-2"},
+				{Label: "eu-west-1"},
+				{Label: "ap-southeast-2"},
+			},
+			StyleCursor: ugform.StyleHelper("black", "white"),
+			StyleText: ugform.StyleHelper("white", "green"),
+			StyleList: ugform.StyleHelper("white", "gray"),
+			StyleHighlight: ugform.StyleHelper("black", "white"),
+			StyleDescription: ugform.StyleHelper("orange", "gray"),
+			ShowDescription: true,
+		},
+	)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	// buttons make submitting and cancelling discoverable
+	err = customForm.AddButton(
+		&ugform.AddButtonInput{
+			Name: "submit",
+			Label: "Submit",
+			Action: ugform.ButtonSubmit,
+			TabOrder: 4,
+			PositionX: 45,
+			PositionY: 30,
+			StyleFocus: ugform.StyleHelper("black", "white"),
+			StyleButton: ugform.StyleHelper("white", "green"),
+		},
+	)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	err = customForm.AddButton(
+		&ugform.AddButtonInput{
+			Name: "cancel",
+			Label: "Cancel",
+			Action: ugform.ButtonCancel,
+			TabOrder: 5,
+			PositionX: 57,
+			PositionY: 30,
+			StyleFocus: ugform.StyleHelper("black", "white"),
+			StyleButton: ugform.StyleHelper("white", "green"),
+		},
+	)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	// form level validators see every value at once and can
+	// attach errors to a field or list them in a summary area
+	customForm.SetSummaryArea(45, 32, 60, 2,
+		ugform.StyleHelper("red", "black"))
+	customForm.AddValidator(func(values map[string]string) []error {
+		if strings.Contains(values["password"], values["role"]) {
+			return []error{&ugform.FieldError{
+				Field: "password",
+				Message: "password must not contain your role",
+			}}
+		}
+		return nil
+	})
 	// you can shift position of the box after creation
 	// by calling the ShiftXY method
 	sampleForm.ShiftXY(3,20)
@@ -205,9 +326,15 @@ func main() {
 	for k, v := range sampleForm.Collect() {
 		fmt.Printf("	%s: '%s'\n", k, v)
 	}
+	// or use Results to get them in tab order with
+	// typed accessors
 	fmt.Println("Results from customForm:")
-	for k, v := range customForm.Collect() {
-		fmt.Printf("	%s: '%s'\n", k, v)
+	results := customForm.Results()
+	for _, field := range results.Fields() {
+		fmt.Printf("	%s: '%s'\n", field.Name, field.Value)
+	}
+	if remember, err := results.Bool("remember"); err == nil {
+		fmt.Printf("	remember is a bool: %t\n", remember)
 	}
 	os.Exit(0)
 }
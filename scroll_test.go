@@ -0,0 +1,62 @@
+package ugform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScrollWindow(t *testing.T) {
+	tests := []struct {
+		text    string
+		off, ci int
+		want    int
+	}{
+		{"abc", 0, 3, 0},
+		{"abcdefghij", 0, 10, 6},
+		{"abcdefghij", 6, 0, 0},
+		// moving within the window leaves it alone
+		{"abcdefghij", 3, 5, 3},
+		{"abcdefghij", 6, 5, 5},
+		// no blank space is left at the end
+		{"abcdefghij", 8, 9, 6},
+		// wide characters take two cells
+		{"日本語です", 0, 5, 3},
+		{"日本語です", 0, 3, 1},
+		// combining marks stay with their base
+		{"e\u0301e\u0301e\u0301e\u0301e\u0301e\u0301", 0, 12, 4},
+	}
+	for _, tt := range tests {
+		_, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 4})
+		tb.con = []rune(tt.text)
+		tb.edited()
+		tb.off, tb.ci = tt.off, tt.ci
+		tb.scroll()
+		if tb.off != tt.want {
+			t.Errorf("scroll(%q) from %d with cursor at %d = %d, want %d",
+				tt.text, tt.off, tt.ci, tb.off, tt.want)
+		}
+	}
+}
+
+func TestScrollLongValue(t *testing.T) {
+	long := strings.Repeat("日本語 abc é ", 250)
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 20, DefaultValue: long})
+	begin := time.Now()
+	f.Start()
+	press(f, tb, tcell.KeyHome, 0, tcell.ModNone)
+	press(f, tb, tcell.KeyEnd, 0, tcell.ModNone)
+	tb.paste([]rune(long + long))
+	for i := 0; i < 100; i++ {
+		press(f, tb, tcell.KeyLeft, 0, tcell.ModNone)
+	}
+	// segmenting the whole value on every lookup took seconds
+	if d := time.Since(begin); d > time.Second {
+		t.Errorf("editing a %d rune value took %v", len(tb.con), d)
+	}
+	if tb.value() != long+long+long {
+		t.Error("pasted text was not inserted at the end")
+	}
+}
@@ -0,0 +1,473 @@
+package ugform
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// cell holds the contents of a single screen cell so that it
+// can be restored after being drawn over
+type cell struct {
+	x, y  int
+	mainc rune
+	combc []rune
+	style tcell.Style
+}
+
+type selectList struct {
+	tabOrder           int
+	name, description  string
+	options            []Option
+	sel, def           int          // index of the chosen and default option
+	px, py, pw, ph     int          // position, width, and popup height
+	fullWidth          int          // width before narrowing to fit the screen
+	cs, ts, ls, hs, ds tcell.Style  // cursor, text, list, highlight, and description style
+	s                  tcell.Screen // need direct access to screen
+	showDescription    bool
+	labelAbove         bool // draw the description above rather than beside
+	focused            bool
+	open               bool
+	filter             []rune // typed filter while the popup is open
+	matches            []int  // indexes of options matching the filter
+	hl                 int    // index within matches that is highlighted
+	top                int    // index within matches of first visible row
+	under              []cell // screen contents underneath the popup
+	errorLine
+}
+
+func (l *selectList) getName() string {
+	return l.name
+}
+
+func (l *selectList) getTabOrder() int {
+	return l.tabOrder
+}
+
+func (l *selectList) getDescription() string {
+	return l.description
+}
+
+// value returns the value of the chosen option
+func (l *selectList) value() string {
+	if len(l.options) == 0 {
+		return ""
+	}
+	return l.options[l.sel].value()
+}
+
+// setValue chooses the option whose value is v
+func (l *selectList) setValue(v string) error {
+	i, err := optionIndex(l.options, v)
+	if err != nil {
+		return err
+	}
+	l.sel = i
+	return nil
+}
+
+func (l *selectList) defaultValue() string {
+	if len(l.options) == 0 {
+		return ""
+	}
+	return l.options[l.def].value()
+}
+
+// clear chooses the first option
+func (l *selectList) clear() {
+	l.sel = 0
+}
+
+// redraw draws the inline portion of the selectList
+func (l *selectList) redraw() {
+	l.draw()
+}
+
+// shift moves the selectList by x and y
+func (l *selectList) shift(x, y int) {
+	l.px += x
+	l.py += y
+}
+
+// drawRow draws text on row y of the selectList padded or
+// truncated to fit the width of the list
+func (l *selectList) drawRow(y int, text []rune, style tcell.Style) {
+	col := drawString(l.s, l.px, y, string(text), l.pw, style)
+	for ; col < l.pw; col++ {
+		l.s.SetContent(l.px+col, y, csr(""), nil, style)
+	}
+}
+
+// draw draws the inline portion of the selectList which shows
+// either the chosen option or the filter while the popup is open
+func (l *selectList) draw() {
+	style := l.ts
+	if l.focused {
+		style = l.cs
+	}
+	text := []rune("")
+	if l.open {
+		text = l.filter
+	} else if len(l.options) > 0 {
+		text = []rune(l.options[l.sel].Label)
+	}
+	l.drawRow(l.py, text, style)
+	arrow := csr("v")
+	if l.open {
+		arrow = csr("^")
+	}
+	l.s.SetContent(l.px+l.pw, l.py, arrow, nil, style)
+	l.s.Show()
+}
+
+// rows returns the number of rows in the popup
+func (l *selectList) rows() int {
+	if l.ph > 0 && l.ph < len(l.options) {
+		return l.ph
+	}
+	if len(l.options) == 0 {
+		return 1
+	}
+	return len(l.options)
+}
+
+// drawPopup draws the visible portion of the filtered options
+// below the inline portion of the selectList
+func (l *selectList) drawPopup() {
+	if l.hl < l.top {
+		l.top = l.hl
+	}
+	if l.hl >= l.top+l.rows() {
+		l.top = l.hl - l.rows() + 1
+	}
+	for row := 0; row < l.rows(); row++ {
+		i := l.top + row
+		text := []rune("")
+		style := l.ls
+		if i < len(l.matches) {
+			text = []rune(l.options[l.matches[i]].Label)
+			if i == l.hl {
+				style = l.hs
+			}
+		} else if row == 0 {
+			text = []rune("no matches")
+		}
+		l.drawRow(l.py+1+row, text, style)
+		l.s.SetContent(l.px+l.pw, l.py+1+row, csr(""), nil, style)
+	}
+	l.s.Show()
+}
+
+// refilter rebuilds the list of options which contain the
+// filter text ignoring case
+func (l *selectList) refilter() {
+	l.matches = l.matches[:0]
+	needle := strings.ToLower(string(l.filter))
+	for i, o := range l.options {
+		if strings.Contains(strings.ToLower(o.Label), needle) {
+			l.matches = append(l.matches, i)
+		}
+	}
+	l.hl = 0
+	l.top = 0
+	for i, m := range l.matches {
+		if m == l.sel {
+			l.hl = i
+		}
+	}
+}
+
+// openPopup remembers what is on screen underneath the popup
+// and then draws it
+func (l *selectList) openPopup() {
+	l.under = l.under[:0]
+	for y := l.py + 1; y <= l.py+l.rows(); y++ {
+		for x := l.px; x <= l.px+l.pw; x++ {
+			mainc, combc, style, _ := l.s.GetContent(x, y)
+			l.under = append(l.under, cell{x, y, mainc, combc, style})
+		}
+	}
+	l.open = true
+	l.filter = l.filter[:0]
+	l.refilter()
+	l.draw()
+	l.drawPopup()
+}
+
+// closePopup restores what was on screen underneath the popup
+func (l *selectList) closePopup() {
+	for _, c := range l.under {
+		l.s.SetContent(c.x, c.y, c.mainc, c.combc, c.style)
+	}
+	l.under = l.under[:0]
+	l.open = false
+	l.draw()
+}
+
+// start draws the selectList and its description
+func (l *selectList) start() {
+	if l.showDescription {
+		drawDescription(l.s, l.px, l.py, l.description, l.labelAbove, l.ds)
+	}
+	l.draw()
+}
+
+// showCursor draws the selectList in its focused style
+func (l *selectList) showCursor() {
+	l.focused = true
+	l.draw()
+}
+
+// hideCursor closes the popup if needed and draws the
+// selectList in its normal style
+func (l *selectList) hideCursor() {
+	l.focused = false
+	if l.open {
+		l.closePopup()
+	}
+	l.draw()
+}
+
+// handleKey opens the popup on Enter or Down and while the popup
+// is open handles navigation, filtering, and choosing. It reports
+// whether or not the key was consumed.
+func (l *selectList) handleKey(ev *tcell.EventKey, a Action) bool {
+	if !l.open {
+		switch {
+		case pressed(ev, tcell.KeyEnter), a == ActionDown:
+			l.openPopup()
+		case a == ActionNone && typed(ev):
+			l.openPopup()
+			l.filter = append(l.filter, ev.Rune())
+			l.refilter()
+			l.draw()
+			l.drawPopup()
+		default:
+			return false
+		}
+		return true
+	}
+	switch {
+	case pressed(ev, tcell.KeyEnter):
+		if len(l.matches) > 0 {
+			l.sel = l.matches[l.hl]
+		}
+		l.closePopup()
+		return true
+	case pressed(ev, tcell.KeyEscape):
+		l.closePopup()
+		return true
+	case a == ActionUp:
+		if l.hl > 0 {
+			l.hl--
+		}
+	case a == ActionDown:
+		if l.hl < len(l.matches)-1 {
+			l.hl++
+		}
+	case a == ActionPageUp:
+		l.hl -= l.rows()
+		if l.hl < 0 {
+			l.hl = 0
+		}
+	case a == ActionPageDown:
+		l.hl += l.rows()
+		if l.hl > len(l.matches)-1 {
+			l.hl = len(l.matches) - 1
+		}
+		if l.hl < 0 {
+			l.hl = 0
+		}
+	case a == ActionNextField, a == ActionPrevField:
+		// close up and let the form move focus
+		l.closePopup()
+		return false
+	case a == ActionBackspace:
+		if len(l.filter) > 0 {
+			l.filter = l.filter[:len(l.filter)-1]
+			l.refilter()
+		}
+	case a == ActionNone && typed(ev):
+		l.filter = append(l.filter, ev.Rune())
+		l.refilter()
+	default:
+		return true
+	}
+	l.draw()
+	l.drawPopup()
+	return true
+}
+
+// bounds returns the area of the screen covered by the inline
+// portion of the selectList
+func (l *selectList) bounds() (x, y, w, h int) {
+	return l.px, l.py, l.pw + 1, 1
+}
+
+// contains reports whether x, y is on the inline portion of the
+// selectList or on its popup while it is open
+func (l *selectList) contains(x, y int) bool {
+	h := 1
+	if l.open {
+		h += l.rows()
+	}
+	return inside(x, y, l.px, l.py, l.pw+1, h)
+}
+
+// click opens or closes the popup when the inline portion is
+// clicked and chooses an option when one in the popup is
+func (l *selectList) click(x, y int) {
+	if !l.open {
+		l.openPopup()
+		return
+	}
+	if i := l.top + y - l.py - 1; y > l.py && i < len(l.matches) {
+		l.sel = l.matches[i]
+	}
+	l.closePopup()
+}
+
+// wheel scrolls the highlight while the popup is open
+func (l *selectList) wheel(n int) bool {
+	if !l.open {
+		return false
+	}
+	l.hl += n
+	if l.hl >= len(l.matches) {
+		l.hl = len(l.matches) - 1
+	}
+	if l.hl < 0 {
+		l.hl = 0
+	}
+	l.drawPopup()
+	return true
+}
+
+// labelSize returns the space taken up by the selectList's
+// description
+func (l *selectList) labelSize() (left, right, above int) {
+	left, above = descriptionSize(l.description, l.showDescription, l.labelAbove)
+	return left, 0, above
+}
+
+func (l *selectList) setLabelAbove(above bool) {
+	l.labelAbove = above
+}
+
+// paste filters the options by the pasted text opening the
+// popup first if needed
+func (l *selectList) paste(text []rune) {
+	if !l.open {
+		l.openPopup()
+	}
+	l.filter = append(l.filter, cleanPaste(text, false)...)
+	l.refilter()
+	l.draw()
+	l.drawPopup()
+}
+
+// AddSelect is a constructor for adding a new select list to the
+// form. The select list shows the chosen option inline and opens
+// a filterable popup list of options below it on Enter or Down.
+// While the popup is open Enter chooses an option rather than
+// submitting the form and Escape closes the popup rather than
+// leaving the form.
+func (f *Form) AddSelect(in *AddSelectInput) (err error) {
+	l := selectList{}
+	l.name = in.Name
+	l.description = in.Description
+	l.options = in.Options
+	for i, o := range l.options {
+		if o.value() == in.DefaultValue {
+			l.def = i
+			break
+		}
+	}
+	l.sel = l.def
+	l.tabOrder = in.TabOrder
+	f.tabOrder[l.tabOrder] = l.name
+	l.s = f.s
+	l.px = in.PositionX
+	l.py = in.PositionY
+	l.pw = in.Width
+	l.fullWidth = in.Width
+	l.ph = in.ListHeight
+	l.cs = in.StyleCursor
+	l.ts = in.StyleText
+	l.ls = in.StyleList
+	l.hs = in.StyleHighlight
+	l.ds = in.StyleDescription
+	l.showDescription = in.ShowDescription
+	l.es = in.StyleError
+	f.components[l.name] = &l
+	if in.HasFocus {
+		f.focus = &l
+	}
+	return err
+}
+
+// AddSelectInput provides all the input parameters for the
+// AddSelect constructor
+type AddSelectInput struct {
+	// Name of the select list which will be included
+	// when collecting results
+	Name string
+
+	// Description of the select list which will be displayed
+	// to the left of it just like a textbox's
+	Description string
+
+	// Options to choose from in the order they are displayed
+	Options []Option
+
+	// The DefaultValue is the Value of the option that is
+	// chosen initially. If no option matches then the
+	// first option is chosen.
+	DefaultValue string
+
+	// TabOrder of the select list for this form. Must be unique
+	// within a form or unstable tab behavior could result
+	TabOrder int
+
+	// PositionX is the x-axis position of the select list
+	PositionX int
+
+	// PositionY is the y-axis position of the select list
+	PositionY int
+
+	// Width is the width of the select list and its popup.
+	// Labels longer than this are truncated.
+	Width int
+
+	// ListHeight is the maximum number of rows shown in the
+	// popup at once. Longer lists scroll. If zero then every
+	// option is shown.
+	ListHeight int
+
+	// tcell Style to use for the select list when it has focus
+	StyleCursor tcell.Style
+
+	// tcell Style to use for the select list when it does not
+	// have focus
+	StyleText tcell.Style
+
+	// tcell Style to use for options in the popup
+	StyleList tcell.Style
+
+	// tcell Style to use for the highlighted option in the popup
+	StyleHighlight tcell.Style
+
+	// tcell Style for the select list's description.
+	StyleDescription tcell.Style
+
+	// Whether or not to show the description to the user.
+	ShowDescription bool
+
+	// tcell Style for error messages attached by form level
+	// validators which are drawn underneath.
+	StyleError tcell.Style
+
+	// Whether or not this select list has focus when the form's
+	// polling method is activated.
+	HasFocus bool
+}
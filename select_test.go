@@ -0,0 +1,109 @@
+package ugform
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestSelect adds a selectList with three options at 5, 1 to
+// a new form on a 40 by 10 simulation screen
+func newTestSelect(t *testing.T) (tcell.SimulationScreen, *Form, *selectList) {
+	t.Helper()
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	err := f.AddSelect(&AddSelectInput{
+		Name: "s", PositionX: 5, PositionY: 1, Width: 10,
+		Options: []Option{
+			{Label: "one", Value: "1"},
+			{Label: "two", Value: "2"},
+			{Label: "three", Value: "3"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, f, f.components["s"].(*selectList)
+}
+
+func TestSelectPopupRestoresScreen(t *testing.T) {
+	s, f, l := newTestSelect(t)
+	// something underneath where the popup opens
+	bold := tcell.StyleDefault.Bold(true)
+	for y := 2; y < 6; y++ {
+		for x, r := range strings.Repeat("under", 8) {
+			s.SetContent(x, y, r, nil, bold)
+		}
+	}
+	f.Start()
+	s.Show()
+	before, _, _ := s.GetContents()
+	before = append([]tcell.SimCell(nil), before...)
+	rows := func() (text []string) {
+		for y := 2; y < 6; y++ {
+			text = append(text, screenText(s, y))
+		}
+		return text
+	}
+	under := rows()
+
+	for _, key := range []tcell.Key{tcell.KeyEscape, tcell.KeyEnter} {
+		press(f, l, tcell.KeyDown, 0, tcell.ModNone)
+		if !l.open {
+			t.Fatal("Down did not open the popup")
+		}
+		if got := screenText(s, 3); !strings.Contains(got, "two") {
+			t.Errorf("popup row shows %q", got)
+		}
+		press(f, l, key, 0, tcell.ModNone)
+		if l.open {
+			t.Fatalf("%s did not close the popup", tcell.KeyNames[key])
+		}
+		if got := rows(); !reflect.DeepEqual(got, under) {
+			t.Errorf("after %s rows = %q, want %q", tcell.KeyNames[key], got, under)
+		}
+		after, _, _ := s.GetContents()
+		for i := 2 * 40; i < 6*40; i++ {
+			if after[i].Style != before[i].Style {
+				t.Errorf("after %s cell %d,%d has style %v, want %v",
+					tcell.KeyNames[key], i%40, i/40, after[i].Style, before[i].Style)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectPopupTakesEnterAndEscape(t *testing.T) {
+	s, f, _ := newTestSelect(t)
+	submitted, cancelled := false, ""
+	f.OnSubmit(func(f *Form) { submitted = true })
+	f.OnCancel(func(f *Form) { cancelled = f.Collect()["s"] })
+	f.Start()
+	interrupt := make(chan struct{})
+	go f.Poll(context.Background(), interrupt, make(chan string, 1))
+	for _, k := range []tcell.Key{
+		tcell.KeyEnter,  // opens the popup
+		tcell.KeyEscape, // closes it without cancelling
+		tcell.KeyEnter,  // opens it again
+		tcell.KeyDown,   // highlights two
+		tcell.KeyEnter,  // chooses two without submitting
+		tcell.KeyEscape, // now cancels the form
+	} {
+		s.InjectKey(k, 0, tcell.ModNone)
+	}
+	select {
+	case <-interrupt:
+	case <-time.After(2 * time.Second):
+		t.Fatal("form never finished")
+	}
+	if submitted {
+		t.Error("Enter in the popup submitted the form")
+	}
+	if cancelled != "2" {
+		t.Errorf("cancelled with %q, want 2", cancelled)
+	}
+}
@@ -0,0 +1,104 @@
+package ugform
+
+// selection returns the start and end (exclusive) indexes
+// within con of the selected text and whether or not there
+// is any selected text
+func (t *textBox) selection() (start, end int, ok bool) {
+	if t.anchor < 0 || t.anchor == t.ci {
+		return 0, 0, false
+	}
+	if t.anchor < t.ci {
+		return t.anchor, t.ci, true
+	}
+	return t.ci, t.anchor, true
+}
+
+// selected reports whether the rune at index pos is selected
+func (t *textBox) selected(pos int) bool {
+	start, end, ok := t.selection()
+	return ok && pos >= start && pos < end
+}
+
+// startSelection anchors a selection at the editing cursor
+// unless one is already underway so that moving the cursor
+// afterwards extends it
+func (t *textBox) startSelection() {
+	if t.anchor < 0 {
+		t.anchor = t.ci
+	}
+}
+
+// unselect drops the selection and redraws if there was one
+func (t *textBox) unselect() {
+	if t.anchor < 0 {
+		return
+	}
+	t.anchor = -1
+	t.drawText()
+}
+
+// selectAll selects the entire contents of the textBox
+func (t *textBox) selectAll() {
+	t.anchor = 0
+	t.moveCursor(len(t.con))
+}
+
+// removeSelection deletes the selected text, if any, without
+// redrawing and reports whether anything was deleted. Callers
+// are expected to record the edit first.
+func (t *textBox) removeSelection() bool {
+	start, end, ok := t.selection()
+	t.anchor = -1
+	if !ok {
+		return false
+	}
+	t.con = append(t.con[:start], t.con[end:]...)
+	t.edited()
+	t.ci = start
+	return true
+}
+
+// deleteSelection deletes the selected text as its own undo
+// step and reports whether there was any to delete
+func (t *textBox) deleteSelection() bool {
+	if _, _, ok := t.selection(); !ok {
+		return false
+	}
+	t.record(editOther, 0)
+	t.removeSelection()
+	t.drawText()
+	return true
+}
+
+// copySelection puts the selected text on the form's clipboard.
+// Password boxes never give up their contents.
+func (t *textBox) copySelection() {
+	start, end, ok := t.selection()
+	if !ok || t.mask {
+		return
+	}
+	if err := t.f.Clipboard.Copy(string(t.con[start:end])); err != nil {
+		log("Error", "copying to clipboard", "error", err)
+	}
+}
+
+// cutSelection puts the selected text on the form's clipboard
+// and then deletes it
+func (t *textBox) cutSelection() {
+	if t.mask {
+		return
+	}
+	t.copySelection()
+	t.deleteSelection()
+}
+
+// pasteClipboard inserts the text on the form's clipboard just
+// as if it had been pasted into the terminal
+func (t *textBox) pasteClipboard() {
+	text, err := t.f.Clipboard.Paste()
+	if err != nil {
+		log("Error", "pasting from clipboard", "error", err)
+		return
+	}
+	t.paste([]rune(text))
+}
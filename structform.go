@@ -0,0 +1,316 @@
+package ugform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultStyles are the styles used for forms generated by
+// FromStruct. Change them before calling FromStruct to restyle
+// generated forms.
+var DefaultStyles = StylesSpec{
+	Cursor:      StyleSpec{Fg: "black", Bg: "white"},
+	Fill:        StyleSpec{Fg: "black", Bg: "grey"},
+	Text:        StyleSpec{Fg: "black", Bg: "grey"},
+	Description: StyleSpec{Fg: "white", Bg: "black"},
+	Error:       StyleSpec{Fg: "red", Bg: "black"},
+	Focus:       StyleSpec{Fg: "black", Bg: "white"},
+	Box:         StyleSpec{Fg: "black", Bg: "grey"},
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// structField ties a form component back to the struct
+// field it was generated from
+type structField struct {
+	name  string
+	value reflect.Value
+}
+
+// FromStruct builds a form from the exported fields of the struct
+// pointed to by v. Each field becomes a textbox, or a checkbox for
+// bool fields, pre-populated with the field's current value. The
+// form is filled back into the struct when it is submitted and
+// values which fail to convert are shown as field errors which
+// block submission.
+//
+// Supported field types are string, bool, all int and uint types,
+// float32, float64, and time.Duration. Fields are controlled with
+// a ugform struct tag holding comma separated options:
+//
+//	Host string `ugform:"name=host,desc=Hostname,width=30"`
+//	Pass string `ugform:"desc=Password,password,required"`
+//	Skip string `ugform:"-"`
+//	Code string `ugform:"max=5,pattern=^[a-z]{2,5}$"`
+//
+// Recognized options are name, desc, width, height, x, y, tab,
+// min, max, pattern, password, multiline, and required. Since
+// regular expressions often hold commas pattern takes the rest
+// of the tag and so must be the last option. Fields
+// without tab follow the largest explicit tab in the order they
+// are declared and two fields with the same tab or name are an
+// error.
+// Fields without y are stacked two rows apart below the lowest
+// explicit y and fields without x leave room on the left for
+// the longest description.
+func FromStruct(s tcell.Screen, v interface{}) (f *Form, err error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return f, errors.New("FromStruct requires a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	spec := FormSpec{Name: rt.Name()}
+	var bound []structField
+	names := make(map[string]string)
+	widest := 0
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get("ugform")
+		if sf.PkgPath != "" || tag == "-" {
+			continue
+		}
+		fs, err := fieldSpec(sf, rv.Field(i), tag)
+		if err != nil {
+			return f, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if prev, ok := names[fs.Name]; ok {
+			return f, fmt.Errorf("fields %s and %s both have name %q", prev, sf.Name, fs.Name)
+		}
+		names[fs.Name] = sf.Name
+		if n := textWidth(fs.Description); n > widest {
+			widest = n
+		}
+		spec.Fields = append(spec.Fields, fs)
+		bound = append(bound, structField{fs.Name, rv.Field(i)})
+	}
+	if err = autoPlace(spec.Fields); err != nil {
+		return f, err
+	}
+	for i := range spec.Fields {
+		if spec.Fields[i].X < 0 {
+			spec.Fields[i].X = widest + 2
+		}
+	}
+	f = NewForm(s)
+	if err = spec.AddTo(f); err != nil {
+		return f, err
+	}
+	f.AddValidator(func(values map[string]string) (errs []error) {
+		for _, b := range bound {
+			if _, err := parseValue(b.value.Type(), values[b.name]); err != nil {
+				errs = append(errs, &FieldError{Field: b.name, Message: err.Error()})
+			}
+		}
+		return errs
+	})
+	f.fill = func() {
+		values := f.Collect()
+		for _, b := range bound {
+			if pv, err := parseValue(b.value.Type(), values[b.name]); err == nil {
+				b.value.Set(pv)
+			}
+		}
+	}
+	return f, err
+}
+
+// autoPlace gives fields without a tab order or y position the
+// next ones after the largest that were given explicitly so that
+// they can't collide. It returns an error if two fields were
+// given the same tab order.
+func autoPlace(fields []FieldSpec) error {
+	tab, y := 0, 0
+	used := make(map[int]string)
+	for _, fs := range fields {
+		if fs.TabOrder >= 0 {
+			if prev, ok := used[fs.TabOrder]; ok {
+				return fmt.Errorf("fields %s and %s both have tab order %d", prev, fs.Name, fs.TabOrder)
+			}
+			used[fs.TabOrder] = fs.Name
+			if fs.TabOrder >= tab {
+				tab = fs.TabOrder + 1
+			}
+		}
+		if fs.Y >= 0 && fs.Y+2 > y {
+			y = fs.Y + 2
+		}
+	}
+	for i := range fields {
+		if fields[i].TabOrder < 0 {
+			fields[i].TabOrder = tab
+			tab++
+		}
+		if fields[i].Y < 0 {
+			fields[i].Y = y
+			y += 2
+		}
+	}
+	return nil
+}
+
+// fieldSpec builds the FieldSpec for a single struct field from
+// its type, current value, and ugform tag
+func fieldSpec(sf reflect.StructField, fv reflect.Value, tag string) (fs FieldSpec, err error) {
+	fs = FieldSpec{
+		Name:            sf.Name,
+		Description:     sf.Name,
+		Width:           20,
+		Height:          1,
+		X:               -1,
+		Y:               -1,
+		TabOrder:        -1,
+		ShowDescription: true,
+		Styles:          DefaultStyles,
+	}
+	if !supported(sf.Type) {
+		return fs, fmt.Errorf("unsupported type %s", sf.Type)
+	}
+	if sf.Type.Kind() == reflect.Bool {
+		fs.Type = "checkbox"
+	}
+	fs.Default = formatValue(fv)
+	for rest := tag; rest != ""; {
+		// pattern takes the rest of the tag commas and all
+		opt := rest
+		rest = ""
+		if !strings.HasPrefix(strings.TrimSpace(opt), "pattern=") {
+			if i := strings.Index(opt, ","); i >= 0 {
+				opt, rest = opt[:i], opt[i+1:]
+			}
+		}
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		key, val := opt, ""
+		if i := strings.Index(opt, "="); i >= 0 {
+			key, val = opt[:i], opt[i+1:]
+		}
+		switch key {
+		case "name":
+			fs.Name = val
+		case "desc":
+			fs.Description = val
+		case "pattern":
+			fs.Pattern = val
+		case "password":
+			fs.Password = true
+		case "multiline":
+			fs.Multiline = true
+		case "required":
+			fs.Required = true
+		case "width", "height", "x", "y", "tab", "min", "max":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return fs, fmt.Errorf("tag option %s needs a number", key)
+			}
+			switch key {
+			case "width":
+				fs.Width = n
+			case "height":
+				fs.Height = n
+			case "x":
+				fs.X = n
+			case "y":
+				fs.Y = n
+			case "tab":
+				fs.TabOrder = n
+			case "min":
+				fs.MinLength = n
+			case "max":
+				fs.MaxLength = n
+			}
+		default:
+			return fs, fmt.Errorf("unknown tag option %q", key)
+		}
+	}
+	return fs, err
+}
+
+// supported reports whether FromStruct can handle type t
+func supported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// formatValue formats the current value of a struct field
+// the way it should appear in the form
+func formatValue(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+	return ""
+}
+
+// parseValue converts s into a value of type t returning an
+// error suitable for showing to the user if it can't
+func parseValue(t reflect.Type, s string) (v reflect.Value, err error) {
+	v = reflect.New(t).Elem()
+	if t.Kind() != reflect.String {
+		s = strings.TrimSpace(s)
+	}
+	if t == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return v, errors.New("must be a duration like 1h30m")
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, errors.New("must be true or false")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return v, fmt.Errorf("must be a whole number that fits in %s", t.Kind())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return v, fmt.Errorf("must be a positive whole number that fits in %s", t.Kind())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return v, errors.New("must be a number")
+		}
+		v.SetFloat(n)
+	default:
+		return v, fmt.Errorf("unsupported type %s", t)
+	}
+	return v, nil
+}
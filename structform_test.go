@@ -0,0 +1,196 @@
+package ugform
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldSpec(t *testing.T) {
+	type config struct {
+		Host    string `ugform:"name=host,desc=Hostname,width=30,x=5,y=7,tab=3"`
+		Pass    string `ugform:"password,required,min=8,max=64,pattern=^[a-z]+$"`
+		Notes   string `ugform:"multiline,height=4"`
+		Code    string `ugform:"required, pattern=^[a-z]{2,5}(,[a-z]{2,5})*$"`
+		Debug   bool
+		Port    int
+		Timeout time.Duration
+		Bad     string `ugform:"width=wide"`
+		Unknown string `ugform:"colour=red"`
+		Map     map[string]string
+	}
+	c := config{Host: "example.com", Debug: true, Port: 8080, Timeout: 90 * time.Second}
+	rt, rv := reflect.TypeOf(c), reflect.ValueOf(c)
+	spec := func(name string) (FieldSpec, error) {
+		sf, _ := rt.FieldByName(name)
+		fv := rv.FieldByName(name)
+		return fieldSpec(sf, fv, sf.Tag.Get("ugform"))
+	}
+
+	fs, err := spec("Host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fs.Name != "host" || fs.Description != "Hostname" || fs.Width != 30 ||
+		fs.X != 5 || fs.Y != 7 || fs.TabOrder != 3 || fs.Default != "example.com" {
+		t.Errorf("Host spec = %+v", fs)
+	}
+
+	fs, err = spec("Pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fs.Password || !fs.Required || fs.MinLength != 8 || fs.MaxLength != 64 || fs.Pattern != "^[a-z]+$" {
+		t.Errorf("Pass spec = %+v", fs)
+	}
+	if fs.Name != "Pass" || fs.Description != "Pass" || fs.X != -1 || fs.Y != -1 || fs.TabOrder != -1 {
+		t.Errorf("Pass defaults = %+v", fs)
+	}
+
+	fs, err = spec("Code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fs.Required || fs.Pattern != "^[a-z]{2,5}(,[a-z]{2,5})*$" {
+		t.Errorf("Code spec = %+v", fs)
+	}
+
+	fs, err = spec("Notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fs.Multiline || fs.Height != 4 {
+		t.Errorf("Notes spec = %+v", fs)
+	}
+
+	fs, err = spec("Debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fs.Type != "checkbox" || fs.Default != "true" {
+		t.Errorf("Debug spec = %+v", fs)
+	}
+
+	if fs, _ = spec("Port"); fs.Type != "" || fs.Default != "8080" {
+		t.Errorf("Port spec = %+v", fs)
+	}
+	if fs, _ = spec("Timeout"); fs.Default != "1m30s" {
+		t.Errorf("Timeout default = %q, want 1m30s", fs.Default)
+	}
+
+	for _, name := range []string{"Bad", "Unknown", "Map"} {
+		if _, err := spec(name); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		in   string
+		want interface{}
+		bad  bool
+	}{
+		{reflect.TypeOf(""), " keep spaces ", " keep spaces ", false},
+		{reflect.TypeOf(false), "true", true, false},
+		{reflect.TypeOf(false), " F ", false, false},
+		{reflect.TypeOf(false), "yes", nil, true},
+		{reflect.TypeOf(0), " -42 ", -42, false},
+		{reflect.TypeOf(0), "4.2", nil, true},
+		{reflect.TypeOf(int8(0)), "127", int8(127), false},
+		{reflect.TypeOf(int8(0)), "128", nil, true},
+		{reflect.TypeOf(int8(0)), "-129", nil, true},
+		{reflect.TypeOf(int16(0)), "-32768", int16(-32768), false},
+		{reflect.TypeOf(int32(0)), "2147483648", nil, true},
+		{reflect.TypeOf(int64(0)), "9223372036854775807", int64(9223372036854775807), false},
+		{reflect.TypeOf(uint(0)), "7", uint(7), false},
+		{reflect.TypeOf(uint(0)), "-1", nil, true},
+		{reflect.TypeOf(uint8(0)), "255", uint8(255), false},
+		{reflect.TypeOf(uint8(0)), "256", nil, true},
+		{reflect.TypeOf(uint16(0)), "65536", nil, true},
+		{reflect.TypeOf(uint32(0)), "4294967295", uint32(4294967295), false},
+		{reflect.TypeOf(uint64(0)), "18446744073709551616", nil, true},
+		{reflect.TypeOf(float32(0)), "1.5", float32(1.5), false},
+		{reflect.TypeOf(float32(0)), "1e39", nil, true},
+		{reflect.TypeOf(float64(0)), "-2.25e3", -2250.0, false},
+		{reflect.TypeOf(float64(0)), "abc", nil, true},
+		{durationType, "1h30m", 90 * time.Minute, false},
+		{durationType, " 250ms ", 250 * time.Millisecond, false},
+		{durationType, "90", nil, true},
+	}
+	for _, tt := range tests {
+		v, err := parseValue(tt.typ, tt.in)
+		if tt.bad {
+			if err == nil {
+				t.Errorf("parseValue(%s, %q) = %v, want an error", tt.typ, tt.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseValue(%s, %q) error %v", tt.typ, tt.in, err)
+			continue
+		}
+		if got := v.Interface(); got != tt.want {
+			t.Errorf("parseValue(%s, %q) = %#v, want %#v", tt.typ, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFromStructAutoTabOrder(t *testing.T) {
+	s := newTestScreen(t, 80, 24)
+	v := struct {
+		B string
+		A string `ugform:"tab=0,y=0"`
+		N int
+		D bool
+	}{}
+	f, err := FromStruct(s, &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	rows := make(map[int]string)
+	for _, c := range f.ordered() {
+		names = append(names, c.getName())
+		_, y, _, _ := c.bounds()
+		if prev, ok := rows[y]; ok {
+			t.Errorf("%s and %s are both on row %d", prev, c.getName(), y)
+		}
+		rows[y] = c.getName()
+	}
+	if got := strings.Join(names, ","); got != "A,B,N,D" {
+		t.Errorf("tab order = %s, want A,B,N,D", got)
+	}
+}
+
+func TestFromStructDuplicateTab(t *testing.T) {
+	s := newTestScreen(t, 80, 24)
+	v := struct {
+		A string `ugform:"tab=1"`
+		B string `ugform:"tab=1"`
+	}{}
+	if _, err := FromStruct(s, &v); err == nil {
+		t.Error("expected an error for two fields with tab=1")
+	}
+}
+
+func TestFromStructDuplicateName(t *testing.T) {
+	s := newTestScreen(t, 80, 24)
+	v := struct {
+		X string `ugform:"name=host"`
+		Y string `ugform:"name=host"`
+	}{}
+	_, err := FromStruct(s, &v)
+	if err == nil || !strings.Contains(err.Error(), `"host"`) {
+		t.Errorf("err = %v, want an error for two fields named host", err)
+	}
+	w := struct {
+		Host string
+		H    string `ugform:"name=Host"`
+	}{}
+	if _, err := FromStruct(s, &w); err == nil {
+		t.Error("expected an error for a name matching another field")
+	}
+}
@@ -2,11 +2,14 @@ package ugform
 
 import (
 	"context"
+	_ "embed"
 	"errors"
 	"github.com/gdamore/tcell/v2"
 	"github.com/inconshreveable/log15"
+	"gopkg.in/yaml.v3"
 	"sort"
 	"time"
+	"unicode"
 )
 
 // Loggo is the global logger. Set this to a log15
@@ -48,9 +51,8 @@ func StyleHelper(fgcolor, bgcolor string) (style tcell.Style) {
 
 // StyleCursor is a helper function that takes a single
 // bgcolor input and returns
-// a tcell Style. Usefull for remembering that setting
-// foreground on a cursor is pointless because no text
-// is ever displayed within a cursor.
+// a tcell Style. The foreground is black so that
+// the character under the cursor remains readable.
 func StyleCursor(bgcolor string) (style tcell.Style) {
 	return StyleHelper("black", bgcolor)
 }
@@ -77,71 +79,614 @@ func csr(s string) int32 {
 	return runes[0]
 }
 
+// component is implemented by every element which can be added
+// to a form and take part in its tab order and focus handling
+type component interface {
+	getName() string
+	getTabOrder() int
+	getDescription() string
+	start()
+	showCursor()
+	hideCursor()
+	handleKey(ev *tcell.EventKey, a Action) bool
+	value() string
+	setValue(v string) error
+	defaultValue() string
+	clear()
+	redraw()
+	shift(x, y int)
+	bounds() (x, y, w, h int)
+	contains(x, y int) bool
+	click(x, y int)
+	wheel(n int) bool
+	paste(text []rune)
+	labelSize() (left, right, above int)
+	setLabelAbove(above bool)
+}
+
 type textBox struct {
 	tabOrder          int
 	name, description string
 	def               string // default to populate contents
 	con               []rune
 	px, py, pw, ph    int          // textBox position and dimensions
-	cx, cy            int          // cursor position
+	fullWidth         int          // width before narrowing to fit the screen
+	cx, cy            int          // cursor position on screen
+	ci                int          // editing cursor index within con
+	anchor            int          // index where the selection started or -1
+	off               int          // index of first rune visible in the box
+	top               int          // index of first line visible in the box
+	lines             []span       // wrapped lines for multi-line boxes
 	cs, ts, fs, ds    tcell.Style  // cursor, text, fill, and description style
+	ss                tcell.Style  // selection style
 	s                 tcell.Screen // need direct access to screen
+	f                 *Form        // form holding the clipboard
 	showDescription   bool
+	labelAbove        bool // draw the description above rather than beside
 	mask              bool // if password box then mask while typing
+	multi             bool // if multi-line box then wrap text over ph rows
+	dir               Direction
+	vi                bool // if vi mode box then Escape switches modes
+	viNormal          bool // whether a vi mode box is in normal mode
+	viPending         rune // vi operator waiting for its motion
+	focused           bool
+	required          bool
+	maxLen            int
+	filter            func(r rune) bool // which runes may be entered
+	validators        []func(string) error
+	hist              history
+	bs                []int // cached cluster boundaries, nil after an edit
+	cw                []int // cached width of each cluster in cells
+	errorLine
+}
+
+// remove handles the removal of the runes from start up to
+// but not including end from the content slice and returns
+// a copy of what was removed
+func (t *textBox) remove(start, end int) []rune {
+	removed := append([]rune(nil), t.con[start:end]...)
+	t.con = append(t.con[:start], t.con[end:]...)
+	t.edited()
+	return removed
+}
+
+func (t *textBox) getName() string {
+	return t.name
+}
+
+func (t *textBox) getTabOrder() int {
+	return t.tabOrder
+}
+
+func (t *textBox) getDescription() string {
+	return t.description
+}
+
+// value returns the contents of the textBox as a string
+func (t *textBox) value() string {
+	return string(t.con)
+}
+
+// setValue replaces the contents of the textBox and moves
+// the editing cursor to the end
+func (t *textBox) setValue(v string) error {
+	if v != string(t.con) {
+		t.record(editOther, 0)
+	}
+	t.con = []rune(v)
+	t.edited()
+	t.ci = len(t.con)
+	t.anchor = -1
+	t.off = 0
+	t.top = 0
+	return nil
+}
+
+func (t *textBox) defaultValue() string {
+	return t.def
+}
+
+// clear empties the textBox
+func (t *textBox) clear() {
+	t.setValue("")
 }
 
-// remove handles the removal of a rune from the content
-// slice for the backspace scenario
-func (t *textBox) remove(pos int) {
-	t.con = append(t.con[:pos], t.con[pos+1:]...)
+// redraw draws the textBox's contents and cursor if focused
+func (t *textBox) redraw() {
+	t.drawText()
+}
+
+// shift moves the textBox and its cursor by x and y
+func (t *textBox) shift(x, y int) {
+	t.px += x
+	t.cx += x
+	t.py += y
+	t.cy += y
 }
 
 // setBox handles drawing of the textBox's container on the screen
 func (t *textBox) setBox() {
-	for i := t.px; i <= t.px+t.pw; i++ {
-		t.s.SetContent(i, t.py, csr(""), nil, t.fs)
+	for j := t.py; j < t.py+t.rows(); j++ {
+		for i := t.px; i <= t.px+t.pw; i++ {
+			t.s.SetContent(i, j, csr(""), nil, t.fs)
+		}
 	}
 }
 
+// rows returns the number of screen rows the textBox occupies
+// which is always one unless it is a multi-line textBox
+func (t *textBox) rows() int {
+	if t.multi && t.ph > 1 {
+		return t.ph
+	}
+	return 1
+}
+
 // hideCursor hides the cursor in its current position
 func (t *textBox) hideCursor() {
-	t.s.SetContent(t.cx, t.cy, csr(""), nil, t.fs)
+	t.focused = false
+	t.drawRow(t.cy - t.py)
 	t.s.Show()
 }
 
 // showCursor shows the cursor in its current position
 func (t *textBox) showCursor() {
-	t.s.SetContent(t.cx, t.cy, csr(" "), nil, t.cs)
+	t.focused = true
+	t.drawRow(t.cy - t.py)
 	t.s.Show()
 }
 
-// setCursor changes the position of the cursor
-func (t *textBox) setCursor(x, y int) {
-	t.s.SetContent(x, y, csr(" "), nil, t.cs)
+// setCursor recalculates the screen position of the cursor
+// from the editing cursor
+func (t *textBox) setCursor() {
+	row, col := t.cursorLine()
+	t.cx = t.px + col
+	t.cy = t.py + row - t.top
 }
 
-// drawDescription draws the textBox's description property
-// to the left of the textBox itself. One must accomodate manually
-// for the length of the description as this will happily draw
-// all the way up to the edge of the screen
-func (t *textBox) drawDescription() {
-	if t.showDescription {
-		sin := make(map[int]rune)
-		slen := 0
-		for j, r := range t.description {
-			slen++
-			sin[j] = r
-		}
-		pos := 0
-		offset := slen + 2
-		start := t.px - offset
-		for i := start; i < start+slen; i++ {
-			if i >= 0 {
-				t.s.SetContent(i, t.py, sin[pos], nil, t.ds)
+// glyphWidth returns the number of cells grapheme cluster c
+// takes up in the textBox. Password boxes draw every cluster
+// as a single asterisk.
+func (t *textBox) glyphWidth(c []rune) int {
+	if t.mask {
+		return 1
+	}
+	return clusterWidth(c)
+}
+
+// width returns the number of cells taken up by the contents
+// from index start up to end
+func (t *textBox) width(start, end int) (w int) {
+	for i := t.cluster(start); i < len(t.cw) && t.bs[i] < end; i++ {
+		w += t.cw[i]
+	}
+	return w
+}
+
+// segment splits the contents into grapheme clusters, caching
+// where each one starts and how many cells it takes up, so that
+// it only happens once per edit rather than on every lookup.
+// The editing cursor only ever sits on a cluster boundary.
+func (t *textBox) segment() {
+	if t.bs != nil {
+		return
+	}
+	t.bs = append(make([]int, 0, len(t.con)+1), 0)
+	t.cw = t.cw[:0]
+	pos := 0
+	for _, c := range graphemes(t.con) {
+		pos += len(c)
+		t.bs = append(t.bs, pos)
+		t.cw = append(t.cw, t.glyphWidth(c))
+	}
+}
+
+// edited drops the cached clusters after the contents change
+func (t *textBox) edited() {
+	t.bs = nil
+}
+
+// cluster returns the index of the grapheme cluster that pos
+// falls within or the number of clusters if pos is at the end
+func (t *textBox) cluster(pos int) int {
+	t.segment()
+	i := sort.SearchInts(t.bs, pos)
+	if i == len(t.bs) || t.bs[i] > pos {
+		i--
+	}
+	return i
+}
+
+// prevBreak returns the start of the grapheme cluster before pos
+func (t *textBox) prevBreak(pos int) int {
+	t.segment()
+	if i := sort.SearchInts(t.bs, pos); i > 0 {
+		return t.bs[i-1]
+	}
+	return 0
+}
+
+// nextBreak returns the end of the grapheme cluster at pos
+func (t *textBox) nextBreak(pos int) int {
+	t.segment()
+	if i := sort.SearchInts(t.bs, pos+1); i < len(t.bs) {
+		return t.bs[i]
+	}
+	return len(t.con)
+}
+
+// glyph is a single grapheme cluster of the contents as it is
+// laid out on one row of the textBox
+type glyph struct {
+	pos, end int // indexes of the cluster within con
+	col, w   int // column and width in cells
+}
+
+// rtl reports whether the textBox lays its text out from
+// right to left
+func (t *textBox) rtl() bool {
+	switch t.dir {
+	case DirectionRTL:
+		return true
+	case DirectionAuto:
+		return isRTL(t.con)
+	}
+	return false
+}
+
+// lineLayout lays out the grapheme clusters of line l that fit
+// within the box in visual order. Right to left boxes are
+// aligned to the right edge. It also returns the column the
+// cursor occupies when it is at the end of the line. Single
+// line boxes only have line zero which starts at the first
+// visible cluster.
+func (t *textBox) lineLayout(l int) (gs []glyph, endCol int) {
+	rtl := t.rtl()
+	if rtl {
+		endCol = t.pw
+	}
+	start, end := t.off, len(t.con)
+	if t.multi {
+		if l >= len(t.lines) {
+			return nil, endCol
+		}
+		start, end = t.lines[l].start, t.lines[l].end
+	} else if l > 0 {
+		return nil, endCol
+	}
+	var cs [][]rune
+	width := 0
+	for i := t.cluster(start); i < len(t.cw) && t.bs[i] < end; i++ {
+		if width+t.cw[i] > t.pw+1 {
+			break
+		}
+		cs = append(cs, t.con[t.bs[i]:t.bs[i+1]])
+		width += t.cw[i]
+	}
+	order := make([]int, len(cs))
+	for i := range order {
+		order[i] = i
+	}
+	if !t.mask {
+		order = visualOrder(cs, rtl)
+	}
+	pos := make([]int, len(cs)+1)
+	pos[0] = start
+	for i, c := range cs {
+		pos[i+1] = pos[i] + len(c)
+	}
+	col := 0
+	if rtl {
+		col = t.pw + 1 - width
+	}
+	endCol = col + width
+	if rtl {
+		endCol = col - 1
+	}
+	for _, i := range order {
+		w := t.glyphWidth(cs[i])
+		gs = append(gs, glyph{pos[i], pos[i+1], col, w})
+		col += w
+	}
+	if endCol < 0 {
+		endCol = 0
+	}
+	if endCol > t.pw {
+		endCol = t.pw
+	}
+	return gs, endCol
+}
+
+// rowGlyphs returns the glyphs visible on row row of the box
+func (t *textBox) rowGlyphs(row int) []glyph {
+	gs, _ := t.lineLayout(t.top + row)
+	return gs
+}
+
+// colAt returns the column of the cursor when it is at index
+// pos on line l
+func (t *textBox) colAt(l, pos int) int {
+	gs, endCol := t.lineLayout(l)
+	for _, g := range gs {
+		if g.pos == pos {
+			return g.col
+		}
+	}
+	return endCol
+}
+
+// posIn returns the index within con of the grapheme cluster
+// displayed at column col of line l or the end of the line if
+// there isn't one there
+func (t *textBox) posIn(l, col int) int {
+	gs, _ := t.lineLayout(l)
+	last := t.off
+	for _, g := range gs {
+		if col >= g.col && col < g.col+g.w {
+			return g.pos
+		}
+		if g.end > last {
+			last = g.end
+		}
+	}
+	if t.multi {
+		if l < len(t.lines) {
+			return t.lineEnd(l)
+		}
+		return len(t.con)
+	}
+	return last
+}
+
+// posAt returns the index within con of the grapheme cluster
+// displayed at column col and row row of the textBox or the
+// end of the line if there isn't one there
+func (t *textBox) posAt(col, row int) int {
+	return t.posIn(t.top+row, col)
+}
+
+// moveVisual moves the editing cursor one cluster to the left
+// on screen if dir is negative or to the right otherwise which
+// in mixed direction text isn't always the previous or next
+// cluster. It falls back to moving through the contents once
+// the cursor reaches the edge of the line.
+func (t *textBox) moveVisual(dir int) {
+	l, _ := t.cursorLine()
+	gs, endCol := t.lineLayout(l)
+	type stop struct{ pos, col int }
+	stops := make([]stop, 0, len(gs)+1)
+	last := t.off
+	for _, g := range gs {
+		stops = append(stops, stop{g.pos, g.col})
+		if g.end > last {
+			last = g.end
+		}
+	}
+	if t.multi && l < len(t.lines) && t.lineEnd(l) == t.lines[l].end {
+		stops = append(stops, stop{t.lines[l].end, endCol})
+	} else if !t.multi && last == len(t.con) {
+		stops = append(stops, stop{last, endCol})
+	}
+	cur := t.cx - t.px
+	best, found := 0, -1
+	for _, s := range stops {
+		if dir < 0 && s.col < cur && (found < 0 || s.col > found) ||
+			dir > 0 && s.col > cur && (found < 0 || s.col < found) {
+			best, found = s.pos, s.col
+		}
+	}
+	if found < 0 {
+		if (dir > 0) != t.rtl() {
+			best = t.nextBreak(t.ci)
+		} else {
+			best = t.prevBreak(t.ci)
+		}
+	}
+	t.moveCursor(best)
+}
+
+// drawRow draws row row of the textBox using the text, fill,
+// selection, or cursor style as appropriate for each cell
+func (t *textBox) drawRow(row int) {
+	y := t.py + row
+	style := func(col int, s tcell.Style) tcell.Style {
+		if t.focused && t.px+col == t.cx && y == t.cy {
+			return t.cs
+		}
+		return s
+	}
+	gs := t.rowGlyphs(row)
+	col := 0
+	if len(gs) > 0 {
+		// right to left rows leave fill to the left of the text
+		for ; col < gs[0].col; col++ {
+			t.s.SetContent(t.px+col, y, csr(""), nil, style(col, t.fs))
+		}
+	}
+	for _, g := range gs {
+		st := t.ts
+		if t.selected(g.pos) {
+			st = t.ss
+		}
+		mainc, combc := csr("*"), []rune(nil)
+		if !t.mask {
+			mainc, combc = t.con[g.pos], t.con[g.pos+1:g.end]
+		}
+		t.s.SetContent(t.px+g.col, y, mainc, combc, style(g.col, st))
+		col = g.col + g.w
+	}
+	for ; col <= t.pw; col++ {
+		t.s.SetContent(t.px+col, y, csr(""), nil, style(col, t.fs))
+	}
+}
+
+// span marks the start and end (exclusive) indexes within con
+// of a single line of text as displayed in a multi-line textBox
+type span struct {
+	start, end int
+}
+
+// wrap splits the contents of a multi-line textBox into the
+// lines that are displayed. Lines break on newlines and are
+// soft wrapped between grapheme clusters when they would
+// exceed the width of the box.
+func (t *textBox) wrap() {
+	t.lines = t.lines[:0]
+	t.segment()
+	start, col := 0, 0
+	for i, w := range t.cw {
+		pos := t.bs[i]
+		if t.con[pos] == '\n' {
+			t.lines = append(t.lines, span{start, pos})
+			start, col = t.bs[i+1], 0
+			continue
+		}
+		if t.pw > 0 && col > 0 && col+w > t.pw {
+			t.lines = append(t.lines, span{start, pos})
+			start, col = pos, 0
+		}
+		col += w
+	}
+	t.lines = append(t.lines, span{start, len(t.con)})
+}
+
+// cursorLine returns the line of the editing cursor within the
+// wrapped lines of a multi-line textBox, which is always zero for
+// single line boxes, and the column it is displayed in
+func (t *textBox) cursorLine() (row, col int) {
+	if !t.multi {
+		return 0, t.colAt(0, t.ci)
+	}
+	for i, l := range t.lines {
+		if l.start > t.ci {
+			break
+		}
+		row = i
+	}
+	return row, t.colAt(row, t.ci)
+}
+
+// lineEnd returns the furthest index the cursor can occupy on
+// line l. A soft wrapped line stops one cluster short of its end
+// since that position belongs to the start of the following line.
+func (t *textBox) lineEnd(l int) int {
+	end := t.lines[l].end
+	if l+1 < len(t.lines) && t.lines[l+1].start == end && end > t.lines[l].start {
+		end = t.prevBreak(end)
+	}
+	return end
+}
+
+// scroll slides the window of visible text so that the
+// editing cursor always stays within the box. The window of
+// a single line box is measured in cells so that wide
+// characters scroll correctly.
+func (t *textBox) scroll() {
+	if t.multi {
+		t.wrap()
+		row, _ := t.cursorLine()
+		if row < t.top {
+			t.top = row
+		}
+		if row >= t.top+t.rows() {
+			t.top = row - t.rows() + 1
+		}
+		if last := len(t.lines) - t.rows(); t.top > last {
+			t.top = last
+			if t.top < 0 {
+				t.top = 0
 			}
-			pos += 1
 		}
+		return
+	}
+	if t.ci < t.off {
+		t.off = t.ci
+	}
+	// walk back from the cursor to find the earliest start
+	// that still leaves room for it
+	lo, w := t.cluster(t.ci), 0
+	for lo > 0 && w+t.cw[lo-1] <= t.pw {
+		lo--
+		w += t.cw[lo]
+	}
+	if t.bs[lo] > t.off {
+		t.off = t.bs[lo]
+	}
+	// pull the window back if there's room left at the end
+	end, w := len(t.cw), 0
+	for end > 0 && w+t.cw[end-1] <= t.pw {
+		end--
+		w += t.cw[end]
+	}
+	if t.bs[end] < t.off {
+		t.off = t.bs[end]
+	}
+}
+
+// drawDescription draws the textBox's description property
+// to the left of the textBox itself, or to the right for right
+// to left descriptions, or on the row above when the layout
+// asks for it. One must accomodate manually for the length of
+// the description as this will happily draw all the way up to
+// the edge of the screen
+func (t *textBox) drawDescription() {
+	if !t.showDescription {
+		return
+	}
+	if !t.rtlDescription() {
+		drawDescription(t.s, t.px, t.py, t.description, t.labelAbove, t.ds)
+		return
+	}
+	// right to left descriptions mirror left to right ones
+	if t.labelAbove {
+		drawString(t.s, t.px+t.pw+1-textWidth(t.description), t.py-1, t.description, -1, t.ds)
+		return
+	}
+	drawString(t.s, t.px+t.pw+3, t.py, t.description, -1, t.ds)
+}
+
+// rtlDescription reports whether the description belongs on
+// the right hand side of the textBox
+func (t *textBox) rtlDescription() bool {
+	return t.dir == DirectionRTL || t.dir == DirectionAuto && isRTL([]rune(t.description))
+}
+
+// labelSize returns the space taken up by the textBox's
+// description which is on the right for right to left boxes
+func (t *textBox) labelSize() (left, right, above int) {
+	side, above := descriptionSize(t.description, t.showDescription, t.labelAbove)
+	if t.rtlDescription() {
+		return 0, side, above
+	}
+	return side, 0, above
+}
+
+func (t *textBox) setLabelAbove(above bool) {
+	t.labelAbove = above
+}
+
+// drawDescription draws a description on row y ending two
+// cells to the left of x or on the row above starting at x.
+// Anything that would fall off the left edge of the screen
+// is not drawn.
+func drawDescription(s tcell.Screen, x, y int, description string, above bool, style tcell.Style) {
+	if above {
+		drawString(s, x, y-1, description, -1, style)
+		return
+	}
+	drawString(s, x-textWidth(description)-2, y, description, -1, style)
+}
+
+// descriptionSize returns the number of cells beside a
+// component or rows above it that drawDescription uses up
+func descriptionSize(description string, show, above bool) (side, rows int) {
+	switch {
+	case !show || description == "":
+		return 0, 0
+	case above:
+		return 0, 1
 	}
+	return textWidth(description) + 2, 0
 }
 
 // start draws the textbox, description, and hides the cursor
@@ -149,11 +694,6 @@ func (t *textBox) start() {
 	t.setBox()
 	t.drawDescription()
 	t.drawText()
-	if t.def != "" && len(t.con) == 0 {
-		for _, r := range t.def {
-			t.add(r)
-		}
-	}
 	t.hideCursor()
 	t.s.Show()
 }
@@ -161,58 +701,370 @@ func (t *textBox) start() {
 // drawText draws the text within the textBox and respects
 // the boundaries of the box in which it is contained. It takes
 // special care to handle the sliding window of text when the text
-// length exceeds the length of the containing box.
+// length exceeds the length of the containing box so that the
+// editing cursor is always visible.
 func (t *textBox) drawText() {
-	var pos int
-	for i := t.px; i < t.px+t.pw; i++ {
-		if len(t.con) > t.pw {
-			pos = (len(t.con) - t.pw) + (i - t.px)
-		} else if len(t.con) <= t.pw {
-			pos = i - t.px
-		} else if i-t.px > len(t.con) {
-			break
-		}
-		if len(t.con) > pos {
-			var char rune
-			if t.mask {
-				char = csr("*")
-			} else {
-				char = t.con[pos]
-			}
-			t.s.SetContent(i, t.py, char, nil, t.ts)
-		}
+	t.scroll()
+	// work out where the cursor goes before drawing over it
+	t.setCursor()
+	for row := 0; row < t.rows(); row++ {
+		t.drawRow(row)
 	}
-	// make sure to set cursor now that it's cleard out
-	t.setCursor(t.cx, t.cy)
 	t.s.Show()
 }
 
-// add handles adding contents to the textBox's contents
-// as new runes are typed and also handles cursor positioning
+// add handles inserting runes into the textBox's contents
+// at the editing cursor as new runes are typed
 func (t *textBox) add(r rune) {
-	t.con = append(t.con, r)
-	if len(t.con) <= t.pw {
-		t.cx += 1
+	if !t.allowed(r) || t.room() == 0 {
+		return
+	}
+	// typing over a selection replaces it in one undo step
+	if _, _, ok := t.selection(); ok {
+		t.record(editOther, 0)
+		t.removeSelection()
+	} else {
+		t.record(editType, r)
+	}
+	t.insert([]rune{r})
+}
+
+// allowed reports whether r passes the textBox's filter.
+// Newlines always pass since only multi-line boxes get them.
+func (t *textBox) allowed(r rune) bool {
+	return r == '\n' || t.filter == nil || t.filter(r)
+}
+
+// room returns how many more runes fit within the maximum length
+// once any selection has been replaced or -1 if there's no limit
+func (t *textBox) room() int {
+	if t.maxLen <= 0 {
+		return -1
+	}
+	start, end, _ := t.selection()
+	if n := t.maxLen - len(t.con) + end - start; n > 0 {
+		return n
 	}
-	t.setCursor(t.cx, t.cy)
+	return 0
+}
+
+// insert puts rs into the textBox's contents at the editing
+// cursor and then draws the result once
+func (t *textBox) insert(rs []rune) {
+	t.con = append(t.con[:t.ci], append(rs, t.con[t.ci:]...)...)
+	t.edited()
+	t.ci += len(rs)
 	t.drawText()
 }
 
-// back handles removal of runes from the textBox's contents
-// as well as handling cursor position and stopping the cursor
-// if the left edge of the box is hit
-func (t *textBox) back() {
-	if len(t.con) > 0 {
-		t.remove(len(t.con) - 1)
-		if len(t.con) < t.pw {
-			t.cx -= 1
-			t.s.SetContent(t.cx+1, t.cy, csr(""), nil, t.ts)
+// paste inserts pasted text in one go replacing any selection.
+// Newlines are only kept for multi-line boxes, runes rejected by
+// the filter are dropped, and the text is cut short at the
+// maximum length.
+func (t *textBox) paste(text []rune) {
+	var rs []rune
+	for _, r := range cleanPaste(text, t.multi) {
+		if t.allowed(r) {
+			rs = append(rs, r)
 		}
-		t.setCursor(t.cx, t.cy)
+	}
+	if room := t.room(); room >= 0 && len(rs) > room {
+		rs = rs[:room]
+	}
+	if _, _, ok := t.selection(); !ok && len(rs) == 0 {
+		return
+	}
+	t.record(editOther, 0)
+	t.removeSelection()
+	t.insert(rs)
+}
+
+// back handles removal of the rune before the editing cursor
+// for the backspace scenario or of the selection if there is
+// one. Nothing happens if the cursor is already at the start
+// of the contents.
+func (t *textBox) back() {
+	if t.deleteSelection() {
+		return
+	}
+	if t.ci > 0 {
+		t.record(editOther, 0)
+		prev := t.prevBreak(t.ci)
+		t.remove(prev, t.ci)
+		t.ci = prev
 	}
 	t.drawText()
 }
 
+// del handles removal of the rune under the editing cursor
+// for the delete key scenario or of the selection if there is one
+func (t *textBox) del() {
+	if t.deleteSelection() {
+		return
+	}
+	if t.ci < len(t.con) {
+		t.record(editOther, 0)
+		t.remove(t.ci, t.nextBreak(t.ci))
+	}
+	t.drawText()
+}
+
+// moveCursor moves the editing cursor to index pos within the
+// contents, clamping to the bounds of the contents
+func (t *textBox) moveCursor(pos int) {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(t.con) {
+		pos = len(t.con)
+	}
+	t.ci = pos
+	t.drawText()
+}
+
+// moveLine moves the editing cursor of a multi-line textBox up
+// or down by n lines keeping the same column where possible
+func (t *textBox) moveLine(n int) {
+	row, col := t.cursorLine()
+	row += n
+	if row < 0 {
+		row = 0
+	}
+	if row >= len(t.lines) {
+		row = len(t.lines) - 1
+	}
+	// find the cluster under the same cell column
+	t.moveCursor(t.posIn(row, col))
+}
+
+// home moves the editing cursor to the start of the current line
+func (t *textBox) home() {
+	if !t.multi {
+		t.moveCursor(0)
+		return
+	}
+	row, _ := t.cursorLine()
+	t.moveCursor(t.lines[row].start)
+}
+
+// end moves the editing cursor to the end of the current line
+func (t *textBox) end() {
+	if !t.multi {
+		t.moveCursor(len(t.con))
+		return
+	}
+	row, _ := t.cursorLine()
+	t.moveCursor(t.lineEnd(row))
+}
+
+// bounds returns the area of the screen covered by the textBox
+func (t *textBox) bounds() (x, y, w, h int) {
+	return t.px, t.py, t.pw + 1, t.rows()
+}
+
+// contains reports whether x, y is within the textBox
+func (t *textBox) contains(x, y int) bool {
+	bx, by, bw, bh := t.bounds()
+	return inside(x, y, bx, by, bw, bh)
+}
+
+// click moves the editing cursor to the rune at x, y or to the
+// end of the line if the click was past the end of the text
+func (t *textBox) click(x, y int) {
+	t.anchor = -1
+	t.moveCursor(t.posAt(x-t.px, y-t.py))
+}
+
+// wheel scrolls a multi-line textBox by n rows dragging the
+// editing cursor along if it would otherwise scroll out of view
+func (t *textBox) wheel(n int) bool {
+	if !t.multi {
+		return false
+	}
+	top := t.top + n
+	if last := len(t.lines) - t.rows(); top > last {
+		top = last
+	}
+	if top < 0 {
+		top = 0
+	}
+	t.top = top
+	row, _ := t.cursorLine()
+	switch {
+	case row < top:
+		t.moveLine(top - row)
+	case row >= top+t.rows():
+		t.moveLine(top + t.rows() - 1 - row)
+	default:
+		t.drawText()
+	}
+	return true
+}
+
+// wordStart returns the index of the start of the word
+// before pos skipping over any spaces in between
+func (t *textBox) wordStart(pos int) int {
+	for pos > 0 && unicode.IsSpace(t.con[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(t.con[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the index of the end of the word after pos
+// skipping over any spaces in between
+func (t *textBox) wordEnd(pos int) int {
+	for pos < len(t.con) && unicode.IsSpace(t.con[pos]) {
+		pos++
+	}
+	for pos < len(t.con) && !unicode.IsSpace(t.con[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// kill removes the runes from start up to end as one undo step
+// and keeps them in the form's kill buffer so they can be
+// yanked back later
+func (t *textBox) kill(start, end int) {
+	if start >= end {
+		return
+	}
+	t.record(editOther, 0)
+	t.f.killed = t.remove(start, end)
+	t.ci = start
+	t.anchor = -1
+	t.drawText()
+}
+
+// lineBounds returns the indexes of the start and end of the
+// line the editing cursor is on
+func (t *textBox) lineBounds() (start, end int) {
+	if !t.multi {
+		return 0, len(t.con)
+	}
+	row, _ := t.cursorLine()
+	return t.lines[row].start, t.lineEnd(row)
+}
+
+// killToEnd kills from the editing cursor to the end of the line
+// or kills the newline itself if the cursor is already there
+func (t *textBox) killToEnd() {
+	_, end := t.lineBounds()
+	if end == t.ci && end < len(t.con) && t.con[end] == '\n' {
+		end++
+	}
+	t.kill(t.ci, end)
+}
+
+// yank inserts the most recently killed text at the editing
+// cursor following the same rules as pasting
+func (t *textBox) yank() {
+	if len(t.f.killed) > 0 {
+		t.paste(t.f.killed)
+	}
+}
+
+// handleKey processes editing keystrokes for the textBox and
+// reports whether or not the key was consumed. Multi-line boxes
+// always take Enter for newlines whatever it is bound to except
+// in vi normal mode.
+func (t *textBox) handleKey(ev *tcell.EventKey, a Action) bool {
+	if t.vi && t.viKey(ev) {
+		return true
+	}
+	if t.multi && !t.viNormal && pressed(ev, tcell.KeyEnter) {
+		t.add('\n')
+		return true
+	}
+	// plain movement drops the selection while shifted
+	// movement extends it
+	switch a {
+	case ActionLeft, ActionRight, ActionHome, ActionEnd,
+		ActionUp, ActionDown, ActionPageUp, ActionPageDown,
+		ActionWordLeft, ActionWordRight:
+		t.unselect()
+	case ActionSelectLeft, ActionSelectRight, ActionSelectHome, ActionSelectEnd:
+		t.startSelection()
+	}
+	switch a {
+	case ActionNone:
+		if !typed(ev) {
+			return false
+		}
+		log("Debug", "detected typing")
+		t.add(ev.Rune())
+	case ActionBackspace:
+		log("Debug", "detected backspace")
+		t.back()
+	case ActionDelete:
+		t.del()
+	case ActionDeleteWord:
+		t.kill(t.wordStart(t.ci), t.ci)
+	case ActionDeleteNextWord:
+		t.kill(t.ci, t.wordEnd(t.ci))
+	case ActionKillToStart:
+		start, _ := t.lineBounds()
+		t.kill(start, t.ci)
+	case ActionKillToEnd:
+		t.killToEnd()
+	case ActionYank:
+		t.yank()
+	case ActionWordLeft:
+		t.moveCursor(t.wordStart(t.ci))
+	case ActionWordRight:
+		t.moveCursor(t.wordEnd(t.ci))
+	case ActionClearField:
+		t.clear()
+		t.drawText()
+	case ActionUndo:
+		t.undo()
+	case ActionRedo:
+		t.redo()
+	case ActionLeft, ActionSelectLeft:
+		t.moveVisual(-1)
+	case ActionRight, ActionSelectRight:
+		t.moveVisual(1)
+	case ActionHome, ActionSelectHome:
+		t.home()
+	case ActionEnd, ActionSelectEnd:
+		t.end()
+	case ActionSelectAll:
+		t.selectAll()
+	case ActionCut:
+		t.cutSelection()
+	case ActionCopy:
+		t.copySelection()
+	case ActionPaste:
+		t.pasteClipboard()
+	case ActionUp:
+		if !t.multi {
+			return false
+		}
+		t.moveLine(-1)
+	case ActionDown:
+		if !t.multi {
+			return false
+		}
+		t.moveLine(1)
+	case ActionPageUp:
+		if !t.multi {
+			return false
+		}
+		t.moveLine(-t.rows())
+	case ActionPageDown:
+		if !t.multi {
+			return false
+		}
+		t.moveLine(t.rows())
+	default:
+		return false
+	}
+	return true
+}
+
 // AddTextBox is a constructor for adding a new textBox to the form
 // paying special attention to setting up tabOrder, instantiating
 // contents, and setting focus. The last box to be added that has the
@@ -224,11 +1076,15 @@ func (f *Form) AddTextBox(in *AddTextBoxInput) (err error) {
 	t.tabOrder = in.TabOrder
 	f.tabOrder[t.tabOrder] = t.name
 	t.s = f.s
-	t.con = make([]rune, 0)
 	t.def = in.DefaultValue
+	t.con = []rune(t.def)
+	t.ci = len(t.con)
+	t.anchor = -1
+	t.f = f
 	t.px = in.PositionX
 	t.py = in.PositionY
 	t.pw = in.Width
+	t.fullWidth = in.Width
 	t.ph = in.Height
 	t.cx = t.px
 	t.cy = t.py
@@ -236,9 +1092,24 @@ func (f *Form) AddTextBox(in *AddTextBoxInput) (err error) {
 	t.fs = in.StyleFill
 	t.ts = in.StyleText
 	t.ds = in.StyleDescription
+	t.ss = in.StyleSelection
+	if t.ss == tcell.StyleDefault {
+		t.ss = t.ts.Reverse(true)
+	}
 	t.mask = in.Password
+	t.multi = in.Multiline
+	t.dir = in.Direction
+	t.vi = in.ViMode
 	t.showDescription = in.ShowDescription
-	f.textBoxes[t.name] = &t
+	t.required = in.Required
+	t.maxLen = in.MaxLength
+	t.filter = in.Filter
+	t.es = in.StyleError
+	t.validators, err = buildValidators(in)
+	if err != nil {
+		return err
+	}
+	f.components[t.name] = &t
 	if in.HasFocus {
 		f.focus = &t
 	}
@@ -255,7 +1126,8 @@ type AddTextBoxInput struct {
 	// Description of the textbox which can be shown to the user
 	// if desired which will be displayed to the left of the
 	// actual textBox. This will be drawn relative to the PositionX
-	// of the textBox itself so plan ahead in your design.
+	// of the textBox itself so plan ahead in your design or let
+	// Form.SetLayout work out positions instead.
 	Description string
 
 	// The DefaultValue will be pre-populated if desired
@@ -277,13 +1149,34 @@ type AddTextBoxInput struct {
 	// user so provide enough room for comfortable usage
 	Width int
 
-	// Height is the height of the textbox. Currently only one
-	// line is supported but you could display a taller box if
-	// you wanted to I guess
+	// Height is the height of the textbox. Only used when
+	// Multiline is set, otherwise the textbox is one line.
 	Height int
 
-	// tcell Style to use for cursor color. Setting the foreground
-	// of the Cursor is pointless as it never contains text.
+	// Multiline turns the textbox into a text area that fills
+	// Height rows and wraps text at Width. Enter inserts a
+	// newline so bind ActionSubmit in the form's Keymap to
+	// something like tcell.KeyCtrlS if you want users to be
+	// able to submit while the text area has focus.
+	Multiline bool
+
+	// ViMode gives the textbox vi style modal editing. It
+	// starts in insert mode and Escape switches to normal mode
+	// where h, l, w, b, 0, and $ move the cursor, x and dw
+	// delete, dd deletes the line, and i, a, and A go back to
+	// insert mode. Escape in normal mode cancels the form as
+	// usual so operators need to press it twice.
+	ViMode bool
+
+	// Direction of the text in the textbox. Right to left
+	// textboxes draw their text from the right edge of the box
+	// and their description to the right of the box. The arrow
+	// keys always move the cursor the way they point on screen.
+	// Defaults to DirectionLTR.
+	Direction Direction
+
+	// tcell Style to use for cursor color. The foreground is
+	// used for the character underneath the cursor.
 	StyleCursor tcell.Style
 
 	// tcell Style to use for textbox fill color. Setting the
@@ -295,6 +1188,10 @@ type AddTextBoxInput struct {
 	// StyleFill's bgcolor.
 	StyleText tcell.Style
 
+	// tcell Style to use for selected text. Defaults to
+	// StyleText in reverse video.
+	StyleSelection tcell.Style
+
 	// tcell Style for the textbox's description.
 	// This uses both foreground and background.
 	StyleDescription tcell.Style
@@ -314,6 +1211,38 @@ type AddTextBoxInput struct {
 	// Indicates whether or not this textbox is a password
 	// field which will mask it's contents while typing.
 	Password bool
+
+	// Required textboxes must not be left empty. Other
+	// validations are skipped for textboxes that are empty
+	// and not required.
+	Required bool
+
+	// MinLength is the minimum number of characters allowed.
+	// Zero means there is no minimum.
+	MinLength int
+
+	// MaxLength is the maximum number of characters allowed.
+	// Typing and pasting stop once it's reached. Zero means
+	// there is no maximum.
+	MaxLength int
+
+	// Filter optionally decides which runes may be typed or
+	// pasted into the textbox, e.g. unicode.IsDigit. Rejected
+	// runes are silently dropped.
+	Filter func(r rune) bool
+
+	// Pattern is a regular expression the contents must match.
+	// AddTextBox returns an error if it does not compile.
+	Pattern string
+
+	// Validate is an optional custom validation func. The
+	// returned error's message is shown to the user.
+	Validate func(value string) error
+
+	// tcell Style for validation error messages which are
+	// drawn on the row underneath the textbox. Leave room
+	// for them in your design.
+	StyleError tcell.Style
 }
 
 // Form contains properties and methods for interacting with its
@@ -321,21 +1250,41 @@ type AddTextBoxInput struct {
 type Form struct {
 	// Optional: name for this form. Useful for managing
 	// lists of forms for example.
-	Name         string
-	SubmitAction interface{}
-	textBoxes    map[string]*textBox
-	tabOrder     map[int]string
-	focus        *textBox // the textbox that has focus
-	interrupt    chan struct{}
-	s            tcell.Screen
+	Name string
+	// Keymap translates keystrokes into actions while polling.
+	// Defaults to a copy of DefaultKeymap which can be changed
+	// or replaced to suit the form.
+	Keymap *Keymap
+	// Clipboard holds text cut or copied from the form's
+	// textboxes. Defaults to a MemoryClipboard of its own.
+	Clipboard  Clipboard
+	components map[string]component
+	tabOrder   map[int]string
+	focus      component        // the component that has focus
+	buttons    tcell.ButtonMask // mouse buttons held at the last event
+	pasting    bool             // whether a bracketed paste is underway
+	pasted     []rune           // text of the paste so far
+	killed     []rune           // text most recently killed for yanking
+	pending    request          // what to do once the current event is handled
+	validators []FormValidator
+	summary    summaryArea
+	layout     *Layout // positions components if set
+	view       *viewport
+	fill       func() // fills a bound struct from FromStruct
+	hooks      map[string]*fieldHooks
+	onSubmit   func(f *Form)
+	onCancel   func(f *Form)
+	started    bool // whether components have been drawn yet
+	interrupt  chan struct{}
+	s          tcell.Screen
 }
 
 // Start activates all of the form's components and renders
 // to the provided screen. If no focus is specified then focus
 // is randomly selected.
 func (f *Form) Start() (err error) {
-	if len(f.textBoxes) == 0 {
-		err = errors.New("no textboxes in form cannot start")
+	if len(f.components) == 0 {
+		err = errors.New("no components in form cannot start")
 		return err
 	}
 	if f.focus == nil {
@@ -348,17 +1297,22 @@ func (f *Form) Start() (err error) {
 		if len(keys) > 0 {
 			log("Debug", "no focus specified so picking lowest taborder")
 			tbNameAtIndex := f.tabOrder[keys[0]]
-			f.focus = f.textBoxes[tbNameAtIndex]
+			f.focus = f.components[tbNameAtIndex]
 		} else {
 			log("Debug", "can't order taborder so picking random focus")
-			for _, tb := range f.textBoxes {
-				f.focus = tb
+			for _, c := range f.components {
+				f.focus = c
 			}
 		}
 	}
-	for _, tb := range f.textBoxes {
-		tb.start()
+	if f.view.bar = f.overflows(); f.view.bar {
+		f.view.top = f.revealTop(f.focus)
+	}
+	for _, c := range f.components {
+		c.start()
 	}
+	f.view.drawScrollbar(f.contentHeight())
+	f.started = true
 	return err
 }
 
@@ -369,17 +1323,19 @@ func (f *Form) tab(direction string) {
 	}
 	// since maps are unordered we have to build an ordered index
 	var keys []int
-	f.focus.hideCursor()
+	if v, ok := f.focus.(validatable); ok {
+		v.validate()
+	}
 	for k, _ := range f.tabOrder {
 		keys = append(keys, k)
 	}
 	sort.Ints(keys)
 	var pos int
 	for i, k := range keys {
-		if f.focus.tabOrder == k && direction == "forward" {
+		if f.focus.getTabOrder() == k && direction == "forward" {
 			pos = i + 1
 		}
-		if f.focus.tabOrder == k && direction == "backward" {
+		if f.focus.getTabOrder() == k && direction == "backward" {
 			pos = i - 1
 		}
 	}
@@ -391,16 +1347,32 @@ func (f *Form) tab(direction string) {
 	}
 	next := f.tabOrder[keys[pos]]
 	log("Debug", "tab", "next", next)
-	f.focus = f.textBoxes[next]
+	f.setFocus(f.components[next])
+}
+
+// setFocus moves focus from the current component to c
+// running the blur and focus hooks along the way
+func (f *Form) setFocus(c component) {
+	if f.focus != nil {
+		f.focus.hideCursor()
+		f.blurred(f.focus)
+	}
+	f.focus = c
+	f.reveal(c)
 	f.focus.showCursor()
+	f.focused(c)
 }
 
 // Collect returns a map of the name and contents of all of the form's
-// textboxes.
+// components. Checkboxes are returned as "true" or "false", use
+// CollectBools if you want them as a bool.
 func (f *Form) Collect() (results map[string]string) {
 	results = make(map[string]string)
-	for _, v := range f.textBoxes {
-		results[v.name] = string(v.con)
+	for _, v := range f.components {
+		if _, ok := v.(*button); ok {
+			continue
+		}
+		results[v.getName()] = v.value()
 	}
 	return results
 }
@@ -413,9 +1385,13 @@ func (f *Form) Collect() (results map[string]string) {
 // the Poll() method.
 func NewForm(s tcell.Screen) (f *Form) {
 	nf := Form{}
-	nf.s = s
-	nf.textBoxes = make(map[string]*textBox)
+	nf.view = &viewport{Screen: s}
+	nf.s = nf.view
+	nf.Keymap = DefaultKeymap()
+	nf.Clipboard = &MemoryClipboard{}
+	nf.components = make(map[string]component)
 	nf.tabOrder = make(map[int]string)
+	nf.hooks = make(map[string]*fieldHooks)
 	return &nf
 }
 
@@ -425,11 +1401,8 @@ func NewForm(s tcell.Screen) (f *Form) {
 // method will not clear the screen so if that's desired
 // you should do it manually.
 func (f *Form) ShiftXY(x, y int) {
-	for _, tb := range f.textBoxes {
-		tb.px += x
-		tb.cx += x
-		tb.py += y
-		tb.cy += y
+	for _, c := range f.components {
+		c.shift(x, y)
 	}
 }
 
@@ -441,78 +1414,18 @@ func (f *Form) ClearShiftXY(x, y int) {
 	f.Start()
 }
 
+//go:embed sample.yaml
+var sampleSpec string
+
 // AddSampleTextBoxes takes an existing form and then adds some
-// basic sample textBoxes
+// basic sample textBoxes as described by the sample.yaml spec
 func AddSampleTextBoxes(nf *Form) (err error) {
-	err = nf.AddTextBox(&AddTextBoxInput{
-		TabOrder:         0,
-		Name:             "test1",
-		DefaultValue:     "Joe",
-		Description:      "What is the name of your favorite childhood friend?",
-		PositionX:        80,
-		PositionY:        5,
-		Height:           1,
-		Width:            10,
-		StyleCursor:      StyleHelper("black", "white").Blink(true),
-		StyleFill:        StyleHelper("black", "grey"),
-		StyleText:        StyleHelper("black", "grey"),
-		StyleDescription: StyleHelper("white", "black"),
-		ShowDescription:  true,
-	})
+	spec := FormSpec{}
+	err = yaml.Unmarshal([]byte(sampleSpec), &spec)
 	if err != nil {
 		return err
 	}
-	err = nf.AddTextBox(&AddTextBoxInput{
-		TabOrder:         2,
-		Name:             "test2",
-		Description:      "Where did you grow up?",
-		PositionX:        80,
-		PositionY:        7,
-		Height:           1,
-		Width:            20,
-		StyleCursor:      StyleHelper("black", "white").Blink(true),
-		StyleFill:        StyleHelper("black", "grey"),
-		StyleText:        StyleHelper("black", "grey"),
-		StyleDescription: StyleHelper("white", "black"),
-		ShowDescription:  true,
-		//HasFocus:         true,
-	})
-	if err != nil {
-		return err
-	}
-	err = nf.AddTextBox(&AddTextBoxInput{
-		TabOrder:         4,
-		Name:             "test3",
-		DefaultValue:     "super long value",
-		Description:      "Age",
-		PositionX:        80,
-		PositionY:        9,
-		Height:           1,
-		Width:            5,
-		StyleCursor:      StyleHelper("black", "white").Blink(true),
-		StyleFill:        StyleHelper("black", "grey"),
-		StyleText:        StyleHelper("black", "grey"),
-		StyleDescription: StyleHelper("white", "black"),
-		ShowDescription:  true,
-	})
-	if err != nil {
-		return err
-	}
-	err = nf.AddTextBox(&AddTextBoxInput{
-		TabOrder:         7,
-		Name:             "test4",
-		Description:      "Weight",
-		PositionX:        80,
-		PositionY:        11,
-		Height:           1,
-		Width:            5,
-		StyleCursor:      StyleHelper("black", "white").Blink(true),
-		StyleFill:        StyleHelper("black", "grey"),
-		StyleText:        StyleHelper("black", "grey"),
-		StyleDescription: StyleHelper("white", "black"),
-		ShowDescription:  true,
-	})
-	return err
+	return spec.AddTo(nf)
 }
 
 func (f *Form) ctxWatcher(ctx context.Context, die chan int) {
@@ -530,6 +1443,26 @@ func (f *Form) ctxWatcher(ctx context.Context, die chan int) {
 	}
 }
 
+// request is something a component asks of the form
+// while handling an event such as a button press
+type request int
+
+const (
+	requestNone request = iota
+	requestSubmit
+	requestCancel
+)
+
+// finish runs the normal exit procedure handing control
+// back to whoever is waiting on the interrupt channel
+func (f *Form) finish(interrupt chan struct{}, die chan int) {
+	f.focus.hideCursor()
+	f.blurred(f.focus)
+	close(interrupt)
+	die <- 0
+	close(die)
+}
+
 type fakeEvent struct{}
 
 func (f fakeEvent) When() time.Time {
@@ -546,48 +1479,85 @@ func (f *Form) Poll(ctx context.Context, interrupt chan struct{}, submit chan st
 	go f.ctxWatcher(ctx, die)
 	log("Info", "starting form poll", "formName", f.Name)
 	f.focus.showCursor()
+	f.focused(f.focus)
 	for {
 		log("Debug", "blocking on PollEvent()")
 		ev := f.s.PollEvent()
 		log("Debug", "caught event")
 		switch ev := ev.(type) {
 		case *tcell.EventKey:
-			switch ev.Key() {
-			case tcell.KeyRune:
-				log("Debug", "detected typing")
-				f.focus.add(ev.Rune())
-			case tcell.KeyTab:
-				f.tab("forward")
-			case tcell.KeyBacktab:
-				f.tab("backward")
-			case tcell.KeyBackspace, tcell.KeyBackspace2:
-				log("Debug", "detected backspace")
-				f.focus.back()
-			case tcell.KeyEnter:
-				// submit form name to given channel
-				log("Info", "sending to submit channel")
-				// indicating desire to Collect()
-				submit <- f.Name
-				// run normal exit procedure
-				f.focus.hideCursor()
-				close(interrupt)
-				die <- 0
-				close(die)
-				return
-			case tcell.KeyEscape:
-				// means we're exiting form focus
-				f.focus.hideCursor()
-				close(interrupt)
-				die <- 0
-				close(die)
-				return
-			default:
-				log("Debug", "detected stroke", "keyStroke", ev.Name())
+			if f.pasting {
+				f.pasteKey(ev)
+				continue
 			}
+			// focused component gets first chance at the key
+			// so that multi-line boxes can take Enter and
+			// buttons can request submit or cancel
+			a := f.Keymap.Lookup(ev)
+			c, old := f.focus, f.focus.value()
+			handled := c.handleKey(ev, a)
+			f.changed(c, old)
+			if handled {
+				// typing after paging away scrolls back
+				f.reveal(c)
+			}
+			if !handled {
+				switch a {
+				case ActionNextField:
+					f.tab("forward")
+				case ActionPrevField:
+					f.tab("backward")
+				case ActionSubmit:
+					f.pending = requestSubmit
+				case ActionCancel:
+					// means we're exiting form focus
+					f.pending = requestCancel
+				case ActionPageUp:
+					f.scroll(-f.page())
+				case ActionPageDown:
+					f.scroll(f.page())
+				default:
+					log("Debug", "detected stroke", "keyStroke", ev.Name())
+				}
+			}
+		case *tcell.EventMouse:
+			f.handleMouse(ev)
+		case *tcell.EventPaste:
+			f.handlePaste(ev)
+		case *tcell.EventResize:
+			f.resize()
 		case fakeEvent:
 			f.focus.hideCursor()
+			f.blurred(f.focus)
 			close(interrupt)
 			return
 		}
+		// keys and clicks can both ask to submit or cancel
+		switch f.pending {
+		case requestSubmit:
+			f.pending = requestNone
+			if !f.valid() {
+				continue
+			}
+			if f.fill != nil {
+				f.fill()
+			}
+			if f.onSubmit != nil {
+				f.onSubmit(f)
+			}
+			// submit form name to given channel
+			log("Info", "sending to submit channel")
+			// indicating desire to Collect()
+			submit <- f.Name
+			f.finish(interrupt, die)
+			return
+		case requestCancel:
+			f.pending = requestNone
+			if f.onCancel != nil {
+				f.onCancel(f)
+			}
+			f.finish(interrupt, die)
+			return
+		}
 	}
 }
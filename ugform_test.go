@@ -0,0 +1,62 @@
+package ugform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestScreen returns an initialized simulation screen of the
+// given size which is finalized when the test ends
+func newTestScreen(t *testing.T, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	s := tcell.NewSimulationScreen("UTF-8")
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	s.SetSize(width, height)
+	t.Cleanup(s.Fini)
+	return s
+}
+
+// screenText returns the text on row y of s with trailing
+// blanks removed
+func screenText(s tcell.SimulationScreen, y int) string {
+	s.Show()
+	cells, width, _ := s.GetContents()
+	var b strings.Builder
+	for x := 0; x < width; x++ {
+		if rs := cells[y*width+x].Runes; len(rs) > 0 {
+			b.WriteString(string(rs))
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// newTestBox adds a textbox described by in to a new form on a
+// simulation screen and returns both
+func newTestBox(t *testing.T, in *AddTextBoxInput) (*Form, *textBox) {
+	t.Helper()
+	f := NewForm(newTestScreen(t, 80, 24))
+	if err := f.AddTextBox(in); err != nil {
+		t.Fatal(err)
+	}
+	return f, f.components[in.Name].(*textBox)
+}
+
+// press sends a keystroke to component c through the form's
+// Keymap the same way Poll does
+func press(f *Form, c component, key tcell.Key, r rune, mod tcell.ModMask) bool {
+	ev := tcell.NewEventKey(key, r, mod)
+	return c.handleKey(ev, f.Keymap.Lookup(ev))
+}
+
+// typeText types each rune of text into component c
+func typeText(f *Form, c component, text string) {
+	for _, r := range text {
+		press(f, c, tcell.KeyRune, r, tcell.ModNone)
+	}
+}
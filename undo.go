@@ -0,0 +1,87 @@
+package ugform
+
+import (
+	"unicode"
+)
+
+// editKind is the kind of change made to a textBox's contents
+// which decides whether it merges with the previous undo step
+type editKind int
+
+const (
+	editNone  editKind = iota
+	editType           // a single typed rune
+	editOther          // deletes, pastes, and programmatic changes
+)
+
+// snapshot is the contents and editing cursor of a textBox
+// before an edit so that the edit can be undone
+type snapshot struct {
+	con []rune
+	ci  int
+}
+
+// history holds a textBox's undo and redo stacks
+type history struct {
+	undo, redo []snapshot
+	last       editKind // kind of the most recent edit
+	lastCI     int      // editing cursor after the most recent rune typed
+	lastRune   rune     // rune typed by the most recent edit
+}
+
+// record saves the current state of the textBox before an edit
+// of the given kind. Typed runes merge into the previous step
+// while the user keeps typing the same word so that undo works
+// a word at a time rather than a keystroke at a time.
+func (t *textBox) record(kind editKind, r rune) {
+	h := &t.hist
+	merge := kind == editType && h.last == editType && t.ci == h.lastCI &&
+		!(unicode.IsSpace(h.lastRune) && !unicode.IsSpace(r))
+	if !merge {
+		h.undo = append(h.undo, t.snapshot())
+	}
+	h.redo = h.redo[:0]
+	h.last = kind
+	h.lastRune = r
+	// typing anywhere but right after this rune starts a new step
+	h.lastCI = t.ci + 1
+}
+
+// snapshot returns a copy of the textBox's current state
+func (t *textBox) snapshot() snapshot {
+	return snapshot{append([]rune(nil), t.con...), t.ci}
+}
+
+// restore puts the textBox back into state s and redraws it
+func (t *textBox) restore(s snapshot) {
+	t.con = s.con
+	t.edited()
+	t.ci = s.ci
+	t.anchor = -1
+	t.hist.last = editNone
+	t.drawText()
+}
+
+// undo reverts the most recent edit if there is one
+func (t *textBox) undo() {
+	h := &t.hist
+	if len(h.undo) == 0 {
+		return
+	}
+	h.redo = append(h.redo, t.snapshot())
+	s := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	t.restore(s)
+}
+
+// redo reapplies the most recently undone edit if there is one
+func (t *textBox) redo() {
+	h := &t.hist
+	if len(h.redo) == 0 {
+		return
+	}
+	h.undo = append(h.undo, t.snapshot())
+	s := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	t.restore(s)
+}
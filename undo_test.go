@@ -0,0 +1,114 @@
+package ugform
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// undoAll undoes every step in t's history returning the value
+// after each one
+func undoAll(f *Form, t *textBox) (values []string) {
+	for len(t.hist.undo) > 0 {
+		press(f, t, tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+		values = append(values, t.value())
+	}
+	return values
+}
+
+func TestUndoMergesWords(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+	typeText(f, tb, "hello  big world")
+	got := undoAll(f, tb)
+	want := []string{"hello  big ", "hello  ", ""}
+	if len(got) != len(want) {
+		t.Fatalf("undo steps = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("undo %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	for _, want := range []string{"hello  ", "hello  big ", "hello  big world"} {
+		press(f, tb, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+		if tb.value() != want {
+			t.Errorf("redo = %q, want %q", tb.value(), want)
+		}
+	}
+}
+
+func TestUndoBreaksOnCursorMove(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+	typeText(f, tb, "ab")
+	press(f, tb, tcell.KeyLeft, 0, tcell.ModNone)
+	typeText(f, tb, "c")
+	if tb.value() != "acb" {
+		t.Fatalf("value = %q, want acb", tb.value())
+	}
+	if got := undoAll(f, tb); len(got) != 2 || got[0] != "ab" {
+		t.Errorf("undo steps = %q, want [ab ]", got)
+	}
+}
+
+func TestUndoDeletesAreOwnSteps(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+	typeText(f, tb, "abc")
+	press(f, tb, tcell.KeyBackspace2, 0, tcell.ModNone)
+	press(f, tb, tcell.KeyBackspace2, 0, tcell.ModNone)
+	typeText(f, tb, "d")
+	got := undoAll(f, tb)
+	want := []string{"a", "ab", "abc", ""}
+	if len(got) != len(want) {
+		t.Fatalf("undo steps = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("undo %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEditClearsRedo(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+	typeText(f, tb, "one two")
+	press(f, tb, tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+	typeText(f, tb, "x")
+	press(f, tb, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	if tb.value() != "one x" {
+		t.Errorf("value = %q, want redo to do nothing after typing", tb.value())
+	}
+}
+
+func TestRecordMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		prev  rune
+		r     rune
+		moved bool
+		merge bool
+	}{
+		{"letters", 'a', 'b', false, true},
+		{"space after word", 'a', ' ', false, true},
+		{"spaces", ' ', ' ', false, true},
+		{"word after space", ' ', 'b', false, false},
+		{"cursor moved", 'a', 'b', true, false},
+	}
+	for _, tt := range tests {
+		_, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+		tb.record(editType, tt.prev)
+		tb.insert([]rune{tt.prev})
+		if tt.moved {
+			tb.moveCursor(0)
+		}
+		tb.record(editType, tt.r)
+		if got := len(tb.hist.undo) == 1; got != tt.merge {
+			t.Errorf("%s: merged = %v, want %v", tt.name, got, tt.merge)
+		}
+	}
+	_, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 40})
+	tb.record(editType, 'a')
+	tb.record(editOther, 0)
+	if len(tb.hist.undo) != 2 {
+		t.Error("other edits should never merge with typing")
+	}
+}
@@ -0,0 +1,280 @@
+package ugform
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// validatable is implemented by components which can check
+// their own contents and display an error message inline
+type validatable interface {
+	component
+	validate() bool
+}
+
+// errorShower is implemented by components which can display
+// an error message attached to them by a form validator
+type errorShower interface {
+	component
+	showError(msg string)
+	shownError() string
+}
+
+// errorLine draws a component's error message on the row
+// underneath it and remembers how much it drew so that the
+// message can be erased again once the error is fixed
+type errorLine struct {
+	msg   string
+	drawn int
+	es    tcell.Style
+}
+
+// drawError draws msg starting at x, y clearing out any
+// previous message first. An empty msg just clears.
+func (e *errorLine) drawError(s tcell.Screen, x, y int, msg string) {
+	for i := 0; i < e.drawn; i++ {
+		s.SetContent(x+i, y, csr(""), nil, tcell.StyleDefault)
+	}
+	e.msg = msg
+	e.drawn = drawString(s, x, y, msg, -1, e.es)
+	s.Show()
+}
+
+// shownError returns the error message currently showing
+func (e *errorLine) shownError() string {
+	return e.msg
+}
+
+// buildValidators turns the validation settings of an
+// AddTextBoxInput into a list of validator funcs in the order
+// they should be checked. It returns an error if Pattern does
+// not compile.
+func buildValidators(in *AddTextBoxInput) (vs []func(string) error, err error) {
+	if in.MinLength > 0 {
+		minLen := in.MinLength
+		vs = append(vs, func(v string) error {
+			if len([]rune(v)) < minLen {
+				return fmt.Errorf("must be at least %d characters", minLen)
+			}
+			return nil
+		})
+	}
+	if in.MaxLength > 0 {
+		maxLen := in.MaxLength
+		vs = append(vs, func(v string) error {
+			if len([]rune(v)) > maxLen {
+				return fmt.Errorf("must be at most %d characters", maxLen)
+			}
+			return nil
+		})
+	}
+	if in.Pattern != "" {
+		pattern := in.Pattern
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return vs, err
+		}
+		vs = append(vs, func(v string) error {
+			if !re.MatchString(v) {
+				return fmt.Errorf("must match %s", pattern)
+			}
+			return nil
+		})
+	}
+	if in.Validate != nil {
+		vs = append(vs, in.Validate)
+	}
+	return vs, err
+}
+
+// check runs the textBox's validators against its contents
+// and returns the first error found. Empty boxes which are not
+// required are always valid.
+func (t *textBox) check() error {
+	v := string(t.con)
+	if v == "" {
+		if t.required {
+			return errors.New("required")
+		}
+		return nil
+	}
+	for _, fn := range t.validators {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the textBox's contents and draws or clears
+// the error message underneath it, reporting whether the
+// contents are valid
+func (t *textBox) validate() bool {
+	msg := ""
+	if err := t.check(); err != nil {
+		msg = err.Error()
+	}
+	t.showError(msg)
+	return msg == ""
+}
+
+// showError draws msg on the row underneath the textBox
+func (t *textBox) showError(msg string) {
+	t.drawError(t.s, t.px, t.py+t.rows(), msg)
+}
+
+// showError draws msg on the row underneath the checkBox
+func (c *checkBox) showError(msg string) {
+	c.drawError(c.s, c.px, c.py+1, msg)
+}
+
+// showError draws msg on the row underneath the last option
+// of the radioGroup
+func (r *radioGroup) showError(msg string) {
+	y := r.py + 1
+	if !r.horizontal {
+		y = r.py + len(r.options)
+	}
+	r.drawError(r.s, r.px, y, msg)
+}
+
+// showError draws msg on the row underneath the selectList
+func (l *selectList) showError(msg string) {
+	l.drawError(l.s, l.px, l.py+1, msg)
+}
+
+// ordered returns the form's components sorted by tab order
+func (f *Form) ordered() (cs []component) {
+	var keys []int
+	for k := range f.tabOrder {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		if c, ok := f.components[f.tabOrder[k]]; ok {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// FieldError is an error returned by a FormValidator which
+// is displayed underneath a particular field of the form
+type FieldError struct {
+	// Field is the Name of the component the error belongs to
+	Field string
+	// Message is displayed to the user
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// FormValidator checks the values of the whole form at once as
+// returned by Collect. Any *FieldError returned is shown under
+// the field it names if that field can display errors, all other
+// errors are shown in the form's summary area. Submission is
+// blocked while any errors remain.
+type FormValidator func(values map[string]string) []error
+
+// AddValidator adds a form level validator which is run
+// whenever the user tries to submit the form
+func (f *Form) AddValidator(v FormValidator) {
+	f.validators = append(f.validators, v)
+}
+
+// SetSummaryArea sets the area of the screen where form level
+// validation errors are listed, one per row. Errors beyond the
+// given number of rows or longer than width are cut off.
+func (f *Form) SetSummaryArea(x, y, width, rows int, style tcell.Style) {
+	f.summary = summaryArea{x: x, y: y, w: width, h: rows, style: style}
+}
+
+// summaryArea is where form level validation errors are drawn
+type summaryArea struct {
+	x, y, w, h int
+	style      tcell.Style
+}
+
+// draw clears the summary area and lists msgs within it
+func (a summaryArea) draw(s tcell.Screen, msgs []string) {
+	for row := 0; row < a.h; row++ {
+		text := ""
+		style := tcell.StyleDefault
+		if row < len(msgs) {
+			text = msgs[row]
+			style = a.style
+		}
+		col := drawString(s, a.x, a.y+row, text, a.w, style)
+		for ; col < a.w; col++ {
+			s.SetContent(a.x+col, a.y+row, csr(""), nil, style)
+		}
+	}
+	s.Show()
+}
+
+// runValidators runs every form level validator and sorts the
+// resulting errors into those that belong to a field of the
+// form and those that belong to the form as a whole
+func (f *Form) runValidators() (fieldErrs map[string]string, formErrs []string) {
+	fieldErrs = make(map[string]string)
+	values := f.Collect()
+	for _, v := range f.validators {
+		for _, err := range v(values) {
+			var fe *FieldError
+			if errors.As(err, &fe) {
+				// fields that can't show an error, such as
+				// buttons, leave it to the summary area
+				if _, ok := f.components[fe.Field].(errorShower); ok {
+					if prev, ok := fieldErrs[fe.Field]; ok {
+						fieldErrs[fe.Field] = prev + "; " + fe.Message
+					} else {
+						fieldErrs[fe.Field] = fe.Message
+					}
+					continue
+				}
+			}
+			formErrs = append(formErrs, err.Error())
+		}
+	}
+	return fieldErrs, formErrs
+}
+
+// valid validates every component in the form along with the
+// form level validators showing any error messages and moves
+// focus to the first invalid component in tab order. It reports
+// whether the whole form is valid.
+func (f *Form) valid() bool {
+	fieldErrs, formErrs := f.runValidators()
+	var first component
+	for _, c := range f.ordered() {
+		ok := true
+		if v, is := c.(validatable); is {
+			ok = v.validate()
+		}
+		if e, is := c.(errorShower); is && ok {
+			msg, bad := fieldErrs[c.getName()]
+			e.showError(msg)
+			ok = !bad
+		}
+		if !ok && first == nil {
+			first = c
+		}
+	}
+	f.summary.draw(f.s, formErrs)
+	if first != nil {
+		log("Debug", "form has invalid fields", "first", first.getName())
+		f.setFocus(first)
+		return false
+	}
+	if len(formErrs) > 0 {
+		log("Debug", "form has errors", "errors", formErrs)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package ugform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFieldErrorOnButtonBlocksSubmit(t *testing.T) {
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	f.AddTextBox(&AddTextBoxInput{Name: "name", TabOrder: 0, PositionX: 5, Width: 10})
+	f.AddButton(&AddButtonInput{Name: "go", Label: "Go", TabOrder: 1, PositionX: 5, PositionY: 2})
+	f.SetSummaryArea(0, 5, 40, 2, StyleHelper("red", "black"))
+	f.AddValidator(func(values map[string]string) []error {
+		return []error{&FieldError{Field: "go", Message: "not yet"}}
+	})
+	if err := f.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if f.valid() {
+		t.Fatal("valid() = true with a field error on a button")
+	}
+	if got := screenText(s, 5); !strings.Contains(got, "go: not yet") {
+		t.Errorf("summary row = %q, want the button's error", got)
+	}
+}
+
+func TestRunValidatorsSortsErrors(t *testing.T) {
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	f.AddTextBox(&AddTextBoxInput{Name: "name", TabOrder: 0, Width: 10})
+	f.AddButton(&AddButtonInput{Name: "go", Label: "Go", TabOrder: 1, PositionY: 2})
+	f.AddValidator(func(values map[string]string) []error {
+		return []error{
+			&FieldError{Field: "name", Message: "too short"},
+			&FieldError{Field: "name", Message: "too dull"},
+			&FieldError{Field: "go", Message: "not yet"},
+			&FieldError{Field: "missing", Message: "gone"},
+			errors.New("whole form"),
+		}
+	})
+	fieldErrs, formErrs := f.runValidators()
+	if got, want := fieldErrs["name"], "too short; too dull"; got != want {
+		t.Errorf("fieldErrs[name] = %q, want %q", got, want)
+	}
+	if len(fieldErrs) != 1 {
+		t.Errorf("fieldErrs = %v, want only name", fieldErrs)
+	}
+	want := []string{"go: not yet", "missing: gone", "whole form"}
+	if strings.Join(formErrs, "|") != strings.Join(want, "|") {
+		t.Errorf("formErrs = %q, want %q", formErrs, want)
+	}
+}
+
+func TestMaxLengthStopsTyping(t *testing.T) {
+	f, tb := newTestBox(t, &AddTextBoxInput{Name: "a", Width: 10, MaxLength: 3})
+	typeText(f, tb, "abcdef")
+	if got := tb.value(); got != "abc" {
+		t.Errorf("typed %q, want abc", got)
+	}
+	tb.paste([]rune("xyz"))
+	if got := tb.value(); got != "abc" {
+		t.Errorf("pasted into a full box giving %q", got)
+	}
+	// replacing a selection frees up its room
+	press(f, tb, tcell.KeyLeft, 0, tcell.ModShift)
+	press(f, tb, tcell.KeyLeft, 0, tcell.ModShift)
+	typeText(f, tb, "xyz")
+	if got := tb.value(); got != "axy" {
+		t.Errorf("typed over a selection giving %q, want axy", got)
+	}
+	tb.setValue("")
+	tb.paste([]rune("abcdef"))
+	if got := tb.value(); got != "abc" {
+		t.Errorf("pasted %q, want abc", got)
+	}
+}
@@ -0,0 +1,85 @@
+package ugform
+
+import (
+	"fmt"
+)
+
+// component returns the named component or an error if the
+// form has no such component
+func (f *Form) component(name string) (component, error) {
+	c, ok := f.components[name]
+	if !ok {
+		return c, fmt.Errorf("no field named %q", name)
+	}
+	return c, nil
+}
+
+// update redraws c after its value changed programmatically.
+// Nothing is drawn until the form has been started.
+func (f *Form) update(c component) {
+	if f.started {
+		c.redraw()
+	}
+}
+
+// Value returns the current value of the named field as it
+// would be returned by Collect
+func (f *Form) Value(name string) (string, error) {
+	c, err := f.component(name)
+	if err != nil {
+		return "", err
+	}
+	return c.value(), nil
+}
+
+// SetValue replaces the value of the named field and redraws
+// it. Textboxes accept any value and place the cursor at the
+// end, checkboxes accept anything strconv.ParseBool does, and
+// radio groups and select lists need the Value of one of their
+// options.
+func (f *Form) SetValue(name, value string) error {
+	c, err := f.component(name)
+	if err != nil {
+		return err
+	}
+	if err = c.setValue(value); err != nil {
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	f.update(c)
+	return nil
+}
+
+// Reset puts every field back to its default value and clears
+// any validation errors. Useful when reusing one form for many
+// records.
+func (f *Form) Reset() {
+	for _, c := range f.components {
+		c.setValue(c.defaultValue())
+		f.update(c)
+	}
+	f.clearErrors()
+}
+
+// Clear empties every textbox, unchecks every checkbox, and
+// selects the first option of radio groups and select lists.
+// Any validation errors are cleared too.
+func (f *Form) Clear() {
+	for _, c := range f.components {
+		c.clear()
+		f.update(c)
+	}
+	f.clearErrors()
+}
+
+// clearErrors erases every validation error on screen
+func (f *Form) clearErrors() {
+	if !f.started {
+		return
+	}
+	for _, c := range f.components {
+		if e, ok := c.(errorShower); ok {
+			e.showError("")
+		}
+	}
+	f.summary.draw(f.s, nil)
+}
@@ -0,0 +1,105 @@
+package ugform
+
+import (
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// viWordStart returns the index of the start of the word after
+// the one at pos the way vi's w motion moves
+func (t *textBox) viWordStart(pos int) int {
+	for pos < len(t.con) && !unicode.IsSpace(t.con[pos]) {
+		pos++
+	}
+	for pos < len(t.con) && unicode.IsSpace(t.con[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// insertMode switches a vi mode textBox back to insert mode
+func (t *textBox) insertMode() {
+	t.viNormal = false
+	t.viPending = 0
+}
+
+// viClamp keeps the cursor of a textBox in normal mode on a
+// character the way vi does by stepping it back when it's past
+// the end of a line that isn't empty
+func (t *textBox) viClamp() {
+	start, end := t.lineBounds()
+	if t.ci >= end && end > start && (end == len(t.con) || t.con[end] == '\n') {
+		t.moveCursor(t.prevBreak(end))
+	}
+}
+
+// viKey handles keys for textBoxes in vi mode and reports whether
+// the key was consumed. Escape in insert mode switches to normal
+// mode and is consumed so that only a second Escape reaches the
+// form and cancels it. In normal mode typed runes are commands
+// and never inserted while other keys carry on to the usual
+// key handling. Commands leave the cursor on a character rather
+// than past the end of the line.
+func (t *textBox) viKey(ev *tcell.EventKey) bool {
+	if !t.viNormal {
+		if !pressed(ev, tcell.KeyEscape) {
+			return false
+		}
+		t.viNormal = true
+		// like vi the cursor steps back onto the last rune typed
+		if start, _ := t.lineBounds(); t.ci > start {
+			t.moveCursor(t.prevBreak(t.ci))
+		}
+		return true
+	}
+	if !typed(ev) {
+		t.viPending = 0
+		return false
+	}
+	t.unselect()
+	r := ev.Rune()
+	if t.viPending == 'd' {
+		t.viPending = 0
+		switch r {
+		case 'w':
+			t.kill(t.ci, t.viWordStart(t.ci))
+		case 'd':
+			start, end := t.lineBounds()
+			t.kill(start, end)
+		}
+		t.viClamp()
+		return true
+	}
+	switch r {
+	case 'h':
+		t.moveVisual(-1)
+	case 'l':
+		t.moveVisual(1)
+	case 'w':
+		t.moveCursor(t.viWordStart(t.ci))
+	case 'b':
+		t.moveCursor(t.wordStart(t.ci))
+	case '0':
+		t.home()
+	case '$':
+		t.end()
+	case 'x':
+		t.del()
+	case 'd':
+		t.viPending = 'd'
+	case 'i':
+		t.insertMode()
+		return true
+	case 'a':
+		t.insertMode()
+		t.moveCursor(t.nextBreak(t.ci))
+		return true
+	case 'A':
+		t.insertMode()
+		t.end()
+		return true
+	}
+	t.viClamp()
+	return true
+}
@@ -0,0 +1,79 @@
+package ugform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestViEscapeThenCancel(t *testing.T) {
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	if err := f.AddTextBox(&AddTextBoxInput{Name: "a", Width: 10, ViMode: true}); err != nil {
+		t.Fatal(err)
+	}
+	cancelled := ""
+	f.OnCancel(func(f *Form) { cancelled = f.Collect()["a"] })
+	f.Start()
+	interrupt := make(chan struct{})
+	go f.Poll(context.Background(), interrupt, make(chan string, 1))
+	// the first Escape only leaves insert mode so the x that
+	// follows deletes the b instead of being typed
+	for _, r := range "ab" {
+		s.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+	s.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	s.InjectKey(tcell.KeyRune, 'x', tcell.ModNone)
+	s.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	select {
+	case <-interrupt:
+	case <-time.After(2 * time.Second):
+		t.Fatal("second Escape did not cancel the form")
+	}
+	if cancelled != "a" {
+		t.Errorf("cancelled with %q, want a", cancelled)
+	}
+}
+
+func TestViNormalMode(t *testing.T) {
+	tests := []struct {
+		text  string
+		multi bool
+		start int
+		keys  string
+		want  string
+		ci    int
+	}{
+		{"hello world", false, 0, "dw", "world", 0},
+		{"hello world", false, 6, "dw", "hello ", 5},
+		{"abc", false, 0, "x", "bc", 0},
+		{"abc", false, 0, "dd", "", 0},
+		{"one\ntwo", true, 5, "dd", "one\n", 4},
+		// the cursor stays on the last character
+		{"hello", false, 0, "$", "hello", 4},
+		{"hello", false, 0, "$x", "hell", 3},
+		{"ab", false, 0, "lll", "ab", 1},
+		{"ab", false, 0, "llx", "a", 0},
+		{"one\ntwo", true, 0, "$x", "on\ntwo", 1},
+		{"abc", false, 2, "0x", "bc", 0},
+		{"abc", false, 0, "b", "abc", 0},
+		// a still appends after the last character
+		{"ab", false, 0, "$ac", "abc", 3},
+	}
+	for _, tt := range tests {
+		f, tb := newTestBox(t, &AddTextBoxInput{
+			Name: "a", Width: 10, Height: 3, Multiline: tt.multi,
+			ViMode: true, DefaultValue: tt.text,
+		})
+		f.Start()
+		press(f, tb, tcell.KeyEscape, 0, tcell.ModNone)
+		tb.moveCursor(tt.start)
+		typeText(f, tb, tt.keys)
+		if got := tb.value(); got != tt.want || tb.ci != tt.ci {
+			t.Errorf("%q from %d after %q = %q at %d, want %q at %d",
+				tt.text, tt.start, tt.keys, got, tb.ci, tt.want, tt.ci)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package ugform
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// viewport is the part of a form that is visible on screen.
+// Components draw in form coordinates and the viewport moves
+// everything up by top rows, clipping whatever falls outside
+// the screen, so that forms taller than the screen can scroll.
+// While the form doesn't fit the rightmost column of the screen
+// holds a scrollbar instead. It keeps track of the cells drawn
+// so that the form can be redrawn without touching anything
+// else on the screen such as other forms.
+type viewport struct {
+	tcell.Screen
+	top   int            // form row shown on the first row of the screen
+	bar   bool           // whether the scrollbar is showing
+	drawn map[point]bool // screen cells drawn since the last erase
+}
+
+// point is the position of a cell on screen
+type point struct {
+	x, y int
+}
+
+// mark records that screen cell x, y has been drawn
+func (v *viewport) mark(x, y int) {
+	if v.drawn == nil {
+		v.drawn = make(map[point]bool)
+	}
+	v.drawn[point{x, y}] = true
+}
+
+// erase blanks every screen cell drawn through the viewport
+func (v *viewport) erase() {
+	for c := range v.drawn {
+		v.Screen.SetContent(c.x, c.y, ' ', nil, tcell.StyleDefault)
+	}
+	v.drawn = nil
+}
+
+// visible reports whether form position x, y is on screen
+func (v *viewport) visible(x, y int) bool {
+	width, height := v.Screen.Size()
+	if v.bar && x >= width-1 {
+		return false
+	}
+	return y >= v.top && y < v.top+height
+}
+
+// SetContent draws at form position x, y if it's on screen
+func (v *viewport) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if v.visible(x, y) {
+		v.mark(x, y-v.top)
+		v.Screen.SetContent(x, y-v.top, mainc, combc, style)
+	}
+}
+
+// SetCell draws at form position x, y if it's on screen
+func (v *viewport) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if v.visible(x, y) {
+		v.mark(x, y-v.top)
+		v.Screen.SetCell(x, y-v.top, style, ch...)
+	}
+}
+
+// GetContent returns what's drawn at form position x, y or a
+// blank cell if it's off screen
+func (v *viewport) GetContent(x, y int) (mainc rune, combc []rune, style tcell.Style, width int) {
+	if !v.visible(x, y) {
+		return ' ', nil, tcell.StyleDefault, 1
+	}
+	return v.Screen.GetContent(x, y-v.top)
+}
+
+// ShowCursor shows the terminal cursor at form position x, y
+// or hides it if that's off screen
+func (v *viewport) ShowCursor(x, y int) {
+	if !v.visible(x, y) {
+		v.Screen.HideCursor()
+		return
+	}
+	v.Screen.ShowCursor(x, y-v.top)
+}
+
+// thumb returns the first row and number of rows of the
+// scrollbar's thumb which shows the part of a form height rows
+// tall that is visible
+func (v *viewport) thumb(height int) (pos, size int) {
+	_, rows := v.Screen.Size()
+	size = rows * rows / height
+	if size < 1 {
+		size = 1
+	}
+	pos = v.top * rows / height
+	if v.top+rows >= height || pos+size > rows {
+		pos = rows - size
+	}
+	return pos, size
+}
+
+// drawScrollbar draws a scrollbar down the rightmost column of
+// the screen for a form height rows tall
+func (v *viewport) drawScrollbar(height int) {
+	if !v.bar {
+		return
+	}
+	width, rows := v.Screen.Size()
+	pos, size := v.thumb(height)
+	for row := 0; row < rows; row++ {
+		r := tcell.RuneVLine
+		if row >= pos && row < pos+size {
+			r = tcell.RuneBlock
+		}
+		v.mark(width-1, row)
+		v.Screen.SetContent(width-1, row, r, nil, tcell.StyleDefault)
+	}
+	v.Screen.Show()
+}
+
+// contentHeight returns the number of rows from the top of the
+// form to the bottom of its summary area or the row underneath
+// its lowest component that's used for errors
+func (f *Form) contentHeight() int {
+	return f.height(1)
+}
+
+// overflows reports whether the form's components and summary
+// area run past the bottom of the screen. The error row under
+// the lowest component doesn't count so that a form which
+// exactly fits never scrolls.
+func (f *Form) overflows() bool {
+	_, height := f.s.Size()
+	return f.height(0) > height
+}
+
+// height returns the number of rows from the top of the form to
+// the bottom of its summary area or extra rows below its lowest
+// component
+func (f *Form) height(extra int) (h int) {
+	for _, c := range f.components {
+		_, y, _, ch := c.bounds()
+		if y+ch+extra > h {
+			h = y + ch + extra
+		}
+	}
+	if bottom := f.summary.y + f.summary.h; bottom > h {
+		h = bottom
+	}
+	return h
+}
+
+// clampTop returns top limited to the rows the form can be
+// scrolled through which is none while it fits on screen
+func (f *Form) clampTop(top int) int {
+	if !f.view.bar {
+		return 0
+	}
+	_, height := f.s.Size()
+	if last := f.contentHeight() - height; top > last {
+		top = last
+	}
+	if top < 0 {
+		top = 0
+	}
+	return top
+}
+
+// scroll moves the view of the form down by n rows, or up if n
+// is negative. It's a no-op for forms that already fit.
+func (f *Form) scroll(n int) {
+	f.scrollTo(f.view.top + n)
+}
+
+// scrollTo scrolls so that form row top is at the top of the
+// screen and redraws the form if that moved anything
+func (f *Form) scrollTo(top int) {
+	if top = f.clampTop(top); top == f.view.top {
+		return
+	}
+	f.view.top = top
+	f.repaint()
+}
+
+// page returns the number of rows PageUp and PageDown scroll
+// which keeps one row from the previous page in view
+func (f *Form) page() int {
+	_, height := f.s.Size()
+	if height > 1 {
+		return height - 1
+	}
+	return 1
+}
+
+// reveal scrolls the form as little as possible so that c, its
+// description, and the row underneath it for errors are all on
+// screen
+func (f *Form) reveal(c component) {
+	f.scrollTo(f.revealTop(c))
+}
+
+// revealTop returns the form row to scroll to for reveal. The
+// top of components taller than the screen wins and forms that
+// fit on screen never scroll.
+func (f *Form) revealTop(c component) int {
+	_, y, _, h := c.bounds()
+	_, _, above := c.labelSize()
+	_, height := f.s.Size()
+	top := f.view.top
+	if y+h+1 > top+height {
+		top = y + h + 1 - height
+	}
+	if y-above < top {
+		top = y - above
+	}
+	return f.clampTop(top)
+}
+
+// clickBar pages up or down when the scrollbar is clicked above
+// or below its thumb at screen row y. It reports whether x was
+// on the scrollbar.
+func (f *Form) clickBar(x, y int) bool {
+	width, _ := f.s.Size()
+	if !f.view.bar || x != width-1 {
+		return false
+	}
+	pos, size := f.view.thumb(f.contentHeight())
+	switch {
+	case y < pos:
+		f.scroll(-f.page())
+	case y >= pos+size:
+		f.scroll(f.page())
+	}
+	return true
+}
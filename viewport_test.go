@@ -0,0 +1,101 @@
+package ugform
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFormThatFitsDoesNotScroll(t *testing.T) {
+	s := newTestScreen(t, 60, 20)
+	f := NewForm(s)
+	for i, y := range []int{0, 19} {
+		err := f.AddTextBox(&AddTextBoxInput{
+			Name: fmt.Sprint("f", y), TabOrder: i, PositionX: 2, PositionY: y,
+			Width: 10, DefaultValue: fmt.Sprint("row", y),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.focus = f.components["f19"]
+	f.Start()
+	if f.view.bar || f.view.top != 0 {
+		t.Errorf("form that fits has bar %v and top %d", f.view.bar, f.view.top)
+	}
+	if got := screenText(s, 19); !strings.HasPrefix(got, "  row19") {
+		t.Errorf("last row shows %q", got)
+	}
+	f.reveal(f.focus)
+	if f.view.top != 0 {
+		t.Errorf("revealing the last field scrolled to %d", f.view.top)
+	}
+}
+
+func TestRepaintOnlyErasesForm(t *testing.T) {
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	for i := 0; i < 10; i++ {
+		err := f.AddTextBox(&AddTextBoxInput{
+			Name: fmt.Sprint("f", i), TabOrder: i, PositionY: i * 2,
+			Width: 5, DefaultValue: fmt.Sprint("r", i),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// something else on the screen beside the form
+	for x, r := range "other" {
+		s.SetContent(20+x, 3, r, nil, tcell.StyleDefault)
+	}
+	f.Start()
+	f.components["f3"].(errorShower).showError("bad")
+	f.scroll(4)
+	if f.view.top != 4 {
+		t.Fatalf("scrolled to %d, want 4", f.view.top)
+	}
+	// screen rows without the scrollbar in the last column
+	row := func(y int) string {
+		rs := []rune(screenText(s, y))
+		return strings.TrimSpace(string(rs[:len(rs)-1]))
+	}
+	want := []string{"r2", "", "r3", "bad", "r4"}
+	for y, w := range want {
+		if y == 3 {
+			w += strings.Repeat(" ", 17) + "other"
+		}
+		if got := row(y); got != w {
+			t.Errorf("row %d shows %q, want %q", y, got, w)
+		}
+	}
+}
+
+func TestLastErrorRowScrollsIntoView(t *testing.T) {
+	s := newTestScreen(t, 40, 10)
+	f := NewForm(s)
+	for i := 0; i < 8; i++ {
+		in := &AddTextBoxInput{
+			Name: fmt.Sprint("f", i), TabOrder: i, PositionY: i * 2,
+			Width: 10, DefaultValue: "ok",
+		}
+		if i == 7 {
+			in.DefaultValue, in.Required = "", true
+		}
+		if err := f.AddTextBox(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.Start()
+	if f.valid() {
+		t.Fatal("form with an empty required field is valid")
+	}
+	if f.focus.getName() != "f7" {
+		t.Fatalf("focus is on %s, want f7", f.focus.getName())
+	}
+	// the error is on form row 15 so the view must reach it
+	if got := screenText(s, 15-f.view.top); !strings.HasPrefix(got, "required") {
+		t.Errorf("top %d, error row shows %q", f.view.top, got)
+	}
+}
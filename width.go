@@ -0,0 +1,68 @@
+package ugform
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// graphemes splits rs into grapheme clusters, the characters a
+// user sees, each of which may be made up of several runes such
+// as a letter followed by combining accents
+func graphemes(rs []rune) (cs [][]rune) {
+	g := uniseg.NewGraphemes(string(rs))
+	i := 0
+	for g.Next() {
+		n := len(g.Runes())
+		cs = append(cs, rs[i:i+n])
+		i += n
+	}
+	return cs
+}
+
+// clusterWidth returns the number of cells grapheme cluster c
+// takes up on screen. CJK characters and most emoji take two.
+// Clusters without any width of their own still get one cell
+// so that they can be seen and edited.
+func clusterWidth(c []rune) int {
+	if w := runewidth.StringWidth(string(c)); w > 0 {
+		return w
+	}
+	return 1
+}
+
+// textWidth returns the number of cells text takes up on screen
+func textWidth(text string) (w int) {
+	for _, c := range graphemes([]rune(text)) {
+		w += clusterWidth(c)
+	}
+	return w
+}
+
+// drawString draws text starting at x, y one grapheme cluster
+// at a time so that wide characters and combining marks line
+// up. Clusters past limit cells are dropped unless limit is
+// negative and the rest are drawn in visual order so that right
+// to left and mixed text reads correctly. Cells left of the
+// screen are skipped. It returns the number of cells drawn.
+func drawString(s tcell.Screen, x, y int, text string, limit int, style tcell.Style) (w int) {
+	rs := []rune(text)
+	var cs [][]rune
+	for _, c := range graphemes(rs) {
+		cw := clusterWidth(c)
+		if limit >= 0 && w+cw > limit {
+			break
+		}
+		cs = append(cs, c)
+		w += cw
+	}
+	col := x
+	for _, i := range visualOrder(cs, isRTL(rs)) {
+		c := cs[i]
+		if col >= 0 {
+			s.SetContent(col, y, c[0], c[1:], style)
+		}
+		col += clusterWidth(c)
+	}
+	return w
+}
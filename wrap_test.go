@@ -0,0 +1,62 @@
+package ugform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []span
+	}{
+		{"", 4, []span{{0, 0}}},
+		{"abc", 4, []span{{0, 3}}},
+		{"abcd", 4, []span{{0, 4}}},
+		{"abcdefghij", 4, []span{{0, 4}, {4, 8}, {8, 10}}},
+		{"ab\ncd", 4, []span{{0, 2}, {3, 5}}},
+		{"ab\n", 4, []span{{0, 2}, {3, 3}}},
+		{"\n\n", 4, []span{{0, 0}, {1, 1}, {2, 2}}},
+		{"abcd\nef", 4, []span{{0, 4}, {5, 7}}},
+		// wide characters take two cells and never split
+		{"日本語", 4, []span{{0, 2}, {2, 3}}},
+		{"a日本", 4, []span{{0, 2}, {2, 3}}},
+		// combining marks stay with their base
+		{"ééé", 2, []span{{0, 4}, {4, 6}}},
+	}
+	for _, tt := range tests {
+		_, tb := newTestBox(t, &AddTextBoxInput{Name: "m", Width: tt.width, Height: 3, Multiline: true})
+		tb.con = []rune(tt.text)
+		tb.wrap()
+		if !reflect.DeepEqual(tb.lines, tt.want) {
+			t.Errorf("wrap(%q, %d) = %v, want %v", tt.text, tt.width, tb.lines, tt.want)
+		}
+	}
+}
+
+func TestLineEnd(t *testing.T) {
+	tests := []struct {
+		text string
+		want []int
+	}{
+		// soft wrapped lines stop short of the wrap point
+		{"abcdefghij", []int{3, 7, 10}},
+		// hard breaks keep the position before the newline
+		{"ab\ncd", []int{2, 5}},
+		{"abcd\nef", []int{4, 7}},
+		{"日本語", []int{1, 3}},
+	}
+	for _, tt := range tests {
+		_, tb := newTestBox(t, &AddTextBoxInput{Name: "m", Width: 4, Height: 3, Multiline: true})
+		tb.con = []rune(tt.text)
+		tb.wrap()
+		var got []int
+		for l := range tb.lines {
+			got = append(got, tb.lineEnd(l))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lineEnd(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}